@@ -0,0 +1,164 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+const defaultBatchConcurrency = 8
+
+// BatchOptions configures batch lookups such as GetMonsters
+type BatchOptions struct {
+	// Concurrency bounds the number of lookups in flight. Defaults to 8.
+	Concurrency int
+}
+
+// BatchError is returned by batch lookups when any key failed. Errors is
+// indexed like Keys, with nil for the keys that were fetched.
+type BatchError struct {
+	Keys   []string
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	failed := e.Failed()
+	for i, err := range e.Errors {
+		if err == nil {
+			continue
+		}
+
+		if len(failed) == 1 {
+			return fmt.Sprintf("batch lookup of %s: %v", e.Keys[i], err)
+		}
+
+		return fmt.Sprintf("batch lookup: %d of %d keys failed, first %s: %v", len(failed), len(e.Keys), e.Keys[i], err)
+	}
+
+	return "batch lookup failed"
+}
+
+// Is reports whether any of the per-key errors matches target, so errors.Is
+// sees through a BatchError on every Go version
+func (e *BatchError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if err != nil && errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first per-key error that matches target, so errors.As sees
+// through a BatchError on every Go version
+func (e *BatchError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if err != nil && errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unwrap returns the per-key errors, for Go 1.20 and later
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Failed returns the failed keys and their errors
+func (e *BatchError) Failed() map[string]error {
+	failed := make(map[string]error)
+	for i, err := range e.Errors {
+		if err != nil {
+			failed[e.Keys[i]] = err
+		}
+	}
+
+	return failed
+}
+
+// Batch calls get for each key on a bounded number of goroutines. The results
+// are in the order of keys, with the zero value for keys that failed; if any
+// did, the error is a *BatchError. Keys not started before ctx is done fail
+// with ctx.Err(). Batch works with any lookup, e.g. client.GetClassContext.
+func Batch[T any](ctx context.Context, keys []string, opts *BatchOptions, get func(ctx context.Context, key string) (T, error)) ([]T, error) {
+	concurrency := defaultBatchConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	results := make([]T, len(keys))
+	errs := make([]error, len(keys))
+	done := make([]bool, len(keys))
+
+	w := &warmer{
+		ctx: ctx,
+		sem: make(chan struct{}, concurrency),
+	}
+
+	for i, key := range keys {
+		i, key := i, key
+		w.run(func(ctx context.Context) error {
+			results[i], errs[i] = get(ctx, key)
+			done[i] = true
+			return nil
+		})
+	}
+
+	w.wg.Wait()
+
+	failed := false
+	for i := range keys {
+		if !done[i] {
+			errs[i] = ctx.Err()
+		}
+
+		if errs[i] != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		return results, &BatchError{Keys: keys, Errors: errs}
+	}
+
+	return results, nil
+}
+
+var (
+	_ BatchInterface = (*dnd5eAPI)(nil)
+	_ BatchInterface = (*CachedClient)(nil)
+)
+
+// GetMonsters fetches the monsters for keys concurrently
+func (c *dnd5eAPI) GetMonsters(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Monster, error) {
+	return Batch(ctx, keys, opts, c.GetMonsterContext)
+}
+
+// GetSpells fetches the spells for keys concurrently
+func (c *dnd5eAPI) GetSpells(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Spell, error) {
+	return Batch(ctx, keys, opts, c.GetSpellContext)
+}
+
+// GetMonsters returns the monsters for keys, serving cached ones directly and
+// fetching the rest concurrently
+func (c *CachedClient) GetMonsters(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Monster, error) {
+	return Batch(ctx, keys, opts, c.GetMonsterContext)
+}
+
+// GetSpells returns the spells for keys, serving cached ones directly and
+// fetching the rest concurrently
+func (c *CachedClient) GetSpells(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Spell, error) {
+	return Batch(ctx, keys, opts, c.GetSpellContext)
+}
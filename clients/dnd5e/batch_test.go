@@ -0,0 +1,151 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	t.Run("preserves the order of keys", func(t *testing.T) {
+		keys := []string{"c", "a", "b", "d"}
+		results, err := Batch(context.Background(), keys, nil, func(ctx context.Context, key string) (string, error) {
+			// finish in reverse order
+			time.Sleep(time.Duration('e'-key[0]) * time.Millisecond)
+			return key + "!", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c!", "a!", "b!", "d!"}, results)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		var inFlight, peak int32
+		keys := make([]string, 20)
+
+		_, err := Batch(context.Background(), keys, &BatchOptions{Concurrency: 3}, func(ctx context.Context, key string) (int, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return 0, nil
+		})
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+	})
+
+	t.Run("returns per-key errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		keys := []string{"goblin", "beholder", "orc"}
+
+		results, err := Batch(context.Background(), keys, nil, func(ctx context.Context, key string) (*entities.Monster, error) {
+			if key == "beholder" {
+				return nil, boom
+			}
+
+			return &entities.Monster{Key: key}, nil
+		})
+
+		var batchErr *BatchError
+		if assert.ErrorAs(t, err, &batchErr) {
+			assert.Equal(t, []error{nil, boom, nil}, batchErr.Errors)
+			assert.Equal(t, map[string]error{"beholder": boom}, batchErr.Failed())
+		}
+		assert.EqualError(t, err, "batch lookup of beholder: boom")
+		assert.True(t, batchErr.Is(boom))
+
+		assert.Equal(t, "goblin", results[0].Key)
+		assert.Nil(t, results[1])
+		assert.Equal(t, "orc", results[2].Key)
+	})
+
+	t.Run("fails keys that never started once ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var once sync.Once
+
+		_, err := Batch(ctx, []string{"a", "b", "c", "d"}, &BatchOptions{Concurrency: 1}, func(ctx context.Context, key string) (string, error) {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+
+			once.Do(cancel)
+			return key, nil
+		})
+
+		var batchErr *BatchError
+		if assert.ErrorAs(t, err, &batchErr) {
+			assert.NotEmpty(t, batchErr.Failed())
+			for _, err := range batchErr.Failed() {
+				assert.ErrorIs(t, err, context.Canceled)
+			}
+		}
+	})
+
+	t.Run("handles no keys", func(t *testing.T) {
+		results, err := Batch(context.Background(), nil, nil, func(ctx context.Context, key string) (string, error) {
+			t.Fatal("unexpected call")
+			return "", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestCachedClient_GetMonsters(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+	mockClient.On("GetMonster", "orc").Return(&entities.Monster{Key: "orc"}, nil).Once()
+	mockClient.On("GetMonster", "beholder").Return(nil, &StatusError{StatusCode: 404}).Once()
+
+	cachedClient := NewCachedClient(mockClient, time.Hour)
+
+	_, err := cachedClient.GetMonster("goblin")
+	assert.NoError(t, err)
+
+	monsters, err := cachedClient.(BatchInterface).GetMonsters(context.Background(), []string{"orc", "beholder", "goblin"}, nil)
+
+	var batchErr *BatchError
+	if assert.ErrorAs(t, err, &batchErr) {
+		assert.ErrorIs(t, batchErr.Errors[1], ErrNotFound)
+
+		var statusErr *StatusError
+		assert.True(t, batchErr.As(&statusErr))
+		assert.Equal(t, 404, statusErr.StatusCode)
+	}
+
+	assert.Equal(t, "orc", monsters[0].Key)
+	assert.Nil(t, monsters[1])
+	assert.Equal(t, "goblin", monsters[2].Key)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDND5eAPI_GetSpells(t *testing.T) {
+	server := newJSONServer(t, map[string]string{
+		"/api/spells/fireball":      `{"index": "fireball", "name": "Fireball", "level": 3}`,
+		"/api/spells/magic-missile": `{"index": "magic-missile", "name": "Magic Missile", "level": 1}`,
+	})
+
+	client, err := NewDND5eAPI(&DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"})
+	assert.NoError(t, err)
+
+	spells, err := client.(BatchInterface).GetSpells(context.Background(), []string{"magic-missile", "fireball"}, &BatchOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, spells, 2) {
+		assert.Equal(t, "Magic Missile", spells[0].Name)
+		assert.Equal(t, "Fireball", spells[1].Name)
+	}
+}
@@ -0,0 +1,205 @@
+package dnd5e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+const defaultWarmConcurrency = 8
+
+// Invalidate removes the entry stored under an exact cache key, e.g. "monster:goblin",
+// from the cache and the shared store, and reports whether it was cached in memory.
+// The key must not include the rules version namespace, which is added here.
+func (c *CachedClient) Invalidate(key string) bool {
+	key = c.namespace + key
+	c.deleteFromStore(key)
+	return c.cache.remove(key)
+}
+
+// InvalidatePrefix removes every entry whose cache key starts with prefix, e.g.
+// "list:" for all lists, and returns how many were removed. Like Invalidate,
+// prefix is matched within the client's rules version namespace.
+func (c *CachedClient) InvalidatePrefix(prefix string) int {
+	prefix = c.namespace + prefix
+	return c.invalidate(func(key string, entry *cacheEntry) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// InvalidateKind removes every list and detail entry of the given resource kind
+// and returns how many were removed.
+func (c *CachedClient) InvalidateKind(kind ResourceKind) int {
+	return c.invalidate(func(key string, entry *cacheEntry) bool {
+		return entry.kind == kind
+	})
+}
+
+// InvalidateAll empties the cache and returns how many entries were removed
+func (c *CachedClient) InvalidateAll() int {
+	return c.invalidate(func(key string, entry *cacheEntry) bool {
+		return true
+	})
+}
+
+// invalidate removes the in-memory entries matching fn and deletes the same keys
+// from the shared store. Keys that are only in the store are not touched, since
+// a store can't be enumerated.
+func (c *CachedClient) invalidate(fn func(key string, entry *cacheEntry) bool) int {
+	removed := c.cache.removeIf(fn)
+
+	for _, item := range removed {
+		c.deleteFromStore(item.key)
+	}
+
+	return len(removed)
+}
+
+// WarmOptions configures CachedClient.Warm
+type WarmOptions struct {
+	// Details also fetches every item referenced by the lists, e.g. each monster.
+	Details bool
+	// Concurrency bounds the number of requests in flight. Defaults to 8.
+	Concurrency int
+}
+
+// WarmError lists the fetches that failed while warming the cache
+type WarmError struct {
+	Errors []error
+}
+
+func (e *WarmError) Error() string {
+	if len(e.Errors) == 1 {
+		return "warming cache: " + e.Errors[0].Error()
+	}
+
+	return fmt.Sprintf("warming cache: %d fetches failed, first: %v", len(e.Errors), e.Errors[0])
+}
+
+// Warm concurrently prefetches every list endpoint into the cache and, if
+// opts.Details is set, every item those lists reference. Failed fetches don't
+// stop the others; they are returned together as a *WarmError.
+func (c *CachedClient) Warm(ctx context.Context, opts *WarmOptions) error {
+	if opts == nil {
+		opts = &WarmOptions{}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultWarmConcurrency
+	}
+
+	w := &warmer{
+		ctx: ctx,
+		sem: make(chan struct{}, concurrency),
+	}
+
+	lists := []struct {
+		list   func(ctx context.Context) ([]*entities.ReferenceItem, error)
+		detail func(ctx context.Context, key string) error
+	}{
+		{c.ListRacesContext, func(ctx context.Context, key string) error {
+			_, err := c.GetRaceContext(ctx, key)
+			return err
+		}},
+		{c.ListEquipmentContext, func(ctx context.Context, key string) error {
+			_, err := c.GetEquipmentContext(ctx, key)
+			return err
+		}},
+		{c.ListClassesContext, func(ctx context.Context, key string) error {
+			_, err := c.GetClassContext(ctx, key)
+			return err
+		}},
+		{func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+			return c.ListSpellsContext(ctx, &ListSpellsInput{})
+		}, func(ctx context.Context, key string) error {
+			_, err := c.GetSpellContext(ctx, key)
+			return err
+		}},
+		{c.ListFeaturesContext, func(ctx context.Context, key string) error {
+			_, err := c.GetFeatureContext(ctx, key)
+			return err
+		}},
+		{c.ListSkillsContext, func(ctx context.Context, key string) error {
+			_, err := c.GetSkillContext(ctx, key)
+			return err
+		}},
+		{c.ListMonstersContext, func(ctx context.Context, key string) error {
+			_, err := c.GetMonsterContext(ctx, key)
+			return err
+		}},
+		{c.ListDamageTypesContext, func(ctx context.Context, key string) error {
+			_, err := c.GetDamageTypeContext(ctx, key)
+			return err
+		}},
+		{c.ListBackgroundsContext, func(ctx context.Context, key string) error {
+			_, err := c.GetBackgroundContext(ctx, key)
+			return err
+		}},
+	}
+
+	for _, l := range lists {
+		l := l
+		w.run(func(ctx context.Context) error {
+			items, err := l.list(ctx)
+			if err != nil || !opts.Details {
+				return err
+			}
+
+			for _, item := range items {
+				key := item.Key
+				w.run(func(ctx context.Context) error {
+					return l.detail(ctx, key)
+				})
+			}
+
+			return nil
+		})
+	}
+
+	w.wg.Wait()
+
+	if len(w.errs) > 0 {
+		return &WarmError{Errors: w.errs}
+	}
+
+	return ctx.Err()
+}
+
+// warmer runs fetches on a bounded number of goroutines and collects their errors
+type warmer struct {
+	ctx  context.Context
+	sem  chan struct{}
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (w *warmer) run(fetch func(ctx context.Context) error) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		if w.ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case w.sem <- struct{}{}:
+		case <-w.ctx.Done():
+			return
+		}
+
+		err := fetch(w.ctx)
+		<-w.sem
+
+		if err != nil && w.ctx.Err() == nil {
+			w.mu.Lock()
+			w.errs = append(w.errs, err)
+			w.mu.Unlock()
+		}
+	}()
+}
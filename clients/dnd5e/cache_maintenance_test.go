@@ -0,0 +1,152 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedClient_Invalidate(t *testing.T) {
+	setup := func() (*MockClient, *CachedClient) {
+		mockClient := new(MockClient)
+		cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+
+		races := []*entities.ReferenceItem{{Key: "dwarf", Name: "Dwarf"}}
+		monsters := []*entities.ReferenceItem{{Key: "goblin", Name: "Goblin"}}
+		mockClient.On("ListRaces").Return(races, nil)
+		mockClient.On("ListMonsters").Return(monsters, nil)
+		mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil)
+
+		cachedClient.ListRaces()
+		cachedClient.ListMonsters()
+		cachedClient.GetMonster("goblin")
+
+		return mockClient, cachedClient
+	}
+
+	t.Run("by exact key", func(t *testing.T) {
+		mockClient, cachedClient := setup()
+
+		assert.True(t, cachedClient.Invalidate("monster:goblin"))
+		assert.False(t, cachedClient.Invalidate("monster:goblin"))
+
+		cachedClient.GetMonster("goblin")
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+		mockClient.AssertNumberOfCalls(t, "ListMonsters", 1)
+	})
+
+	t.Run("by prefix", func(t *testing.T) {
+		mockClient, cachedClient := setup()
+
+		assert.Equal(t, 2, cachedClient.InvalidatePrefix("list:"))
+
+		cachedClient.ListRaces()
+		cachedClient.GetMonster("goblin")
+		mockClient.AssertNumberOfCalls(t, "ListRaces", 2)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+	})
+
+	t.Run("by kind", func(t *testing.T) {
+		mockClient, cachedClient := setup()
+
+		assert.Equal(t, 2, cachedClient.InvalidateKind(ResourceMonster))
+
+		cachedClient.ListRaces()
+		cachedClient.ListMonsters()
+		mockClient.AssertNumberOfCalls(t, "ListRaces", 1)
+		mockClient.AssertNumberOfCalls(t, "ListMonsters", 2)
+	})
+
+	t.Run("entirely", func(t *testing.T) {
+		_, cachedClient := setup()
+
+		assert.Equal(t, 3, cachedClient.InvalidateAll())
+		assert.Equal(t, 0, cachedClient.cache.len())
+	})
+}
+
+func TestCachedClient_Warm(t *testing.T) {
+	setupLists := func(mockClient *MockClient) {
+		empty := []*entities.ReferenceItem{}
+		mockClient.On("ListRaces").Return([]*entities.ReferenceItem{{Key: "dwarf"}, {Key: "elf"}}, nil).Once()
+		mockClient.On("ListEquipment").Return(empty, nil).Once()
+		mockClient.On("ListClasses").Return(empty, nil).Once()
+		mockClient.On("ListSpells", &ListSpellsInput{}).Return([]*entities.ReferenceItem{{Key: "shield"}}, nil).Once()
+		mockClient.On("ListFeatures").Return(empty, nil).Once()
+		mockClient.On("ListSkills").Return(empty, nil).Once()
+		mockClient.On("ListMonsters").Return(empty, nil).Once()
+		mockClient.On("ListDamageTypes").Return(empty, nil).Once()
+		mockClient.On("ListBackgrounds").Return(empty, nil).Once()
+	}
+
+	t.Run("prefetches every list", func(t *testing.T) {
+		mockClient := new(MockClient)
+		cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+		setupLists(mockClient)
+
+		err := cachedClient.Warm(context.Background(), nil)
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+
+		// served from cache now
+		races, err := cachedClient.ListRaces()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(races))
+		assert.Equal(t, 9, cachedClient.cache.len())
+	})
+
+	t.Run("prefetches details when asked", func(t *testing.T) {
+		mockClient := new(MockClient)
+		cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+		setupLists(mockClient)
+		mockClient.On("GetRace", "dwarf").Return(&entities.Race{Key: "dwarf"}, nil).Once()
+		mockClient.On("GetRace", "elf").Return(&entities.Race{Key: "elf"}, nil).Once()
+		mockClient.On("GetSpell", "shield").Return(&entities.Spell{Key: "shield"}, nil).Once()
+
+		err := cachedClient.Warm(context.Background(), &WarmOptions{Details: true, Concurrency: 2})
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+
+		_, err = cachedClient.GetRace("elf")
+		assert.NoError(t, err)
+		mockClient.AssertNumberOfCalls(t, "GetRace", 2)
+	})
+
+	t.Run("collects failures without stopping", func(t *testing.T) {
+		mockClient := new(MockClient)
+		cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+		apiErr := errors.New("API error")
+		mockClient.On("ListRaces").Return(nil, apiErr).Once()
+		mockClient.On("ListMonsters").Return(nil, apiErr).Once()
+		mockClient.On("ListEquipment").Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListClasses").Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListSpells", &ListSpellsInput{}).Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListFeatures").Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListSkills").Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListDamageTypes").Return([]*entities.ReferenceItem{}, nil).Once()
+		mockClient.On("ListBackgrounds").Return([]*entities.ReferenceItem{}, nil).Once()
+
+		err := cachedClient.Warm(context.Background(), nil)
+
+		var warmErr *WarmError
+		assert.True(t, errors.As(err, &warmErr))
+		assert.Equal(t, 2, len(warmErr.Errors))
+		assert.Equal(t, 7, cachedClient.cache.len())
+	})
+
+	t.Run("stops when ctx is cancelled", func(t *testing.T) {
+		mockClient := new(MockClient)
+		cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := cachedClient.Warm(ctx, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		mockClient.AssertNotCalled(t, "ListRaces")
+	})
+}
@@ -0,0 +1,55 @@
+package dnd5e
+
+import (
+	"context"
+	"time"
+)
+
+// sharedFetchTimeout bounds fetches that run detached from their callers, so
+// one that hangs doesn't block its cache key forever
+const sharedFetchTimeout = time.Minute
+
+// revalidate refreshes cacheKey in the background unless a fetch for it is
+// already in flight. The refresh keeps ctx's values but not its cancellation,
+// since the caller has already been answered with the stale value.
+func (c *CachedClient) revalidate(ctx context.Context, kind ResourceKind, cacheKey string, fetch func(ctx context.Context) (interface{}, error)) {
+	c.flights.doAsync(cacheKey, func() (interface{}, error) {
+		ctx, cancel := detach(ctx)
+		defer cancel()
+
+		result, err := fetch(ctx)
+		if err != nil {
+			c.stats.record(CacheEventError, kind, cacheKey, err)
+			c.log().Warn("background refresh failed", "kind", kind, "cache_key", cacheKey, "error", err)
+		}
+
+		return result, err
+	})
+}
+
+// detach returns a context with the values of ctx but not its cancellation,
+// which times out after sharedFetchTimeout
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(detachedContext{ctx}, sharedFetchTimeout)
+}
+
+// detachedContext carries the values of its parent but is never cancelled
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
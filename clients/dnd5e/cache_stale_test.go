@@ -0,0 +1,102 @@
+package dnd5e
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCachedClientWithConfig_Stale(t *testing.T) {
+	t.Run("cfg.MaxStale is required", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{Client: new(MockClient), StaleIfError: true})
+		assert.EqualError(t, err, "cfg.MaxStale is required with cfg.StaleWhileRevalidate or cfg.StaleIfError")
+	})
+
+	t.Run("cfg.MaxStale must not be negative", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{Client: new(MockClient), MaxStale: -time.Second})
+		assert.EqualError(t, err, "cfg.MaxStale must not be negative")
+	})
+}
+
+func TestCachedClient_StaleIfError(t *testing.T) {
+	goblin := &entities.Monster{Key: "goblin", Name: "Goblin"}
+	upstreamErr := errors.New("upstream down")
+
+	newClient := func(maxStale time.Duration) (*MockClient, *CachedClient) {
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil).Once()
+		mockClient.On("GetMonster", "goblin").Return(nil, upstreamErr)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+			Client:       mockClient,
+			TTL:          10 * time.Millisecond,
+			StaleIfError: true,
+			MaxStale:     maxStale,
+		})
+		assert.NoError(t, err)
+
+		return mockClient, cachedClient
+	}
+
+	t.Run("serves the last value when the upstream fails", func(t *testing.T) {
+		mockClient, cachedClient := newClient(time.Hour)
+
+		cachedClient.GetMonster("goblin")
+		time.Sleep(20 * time.Millisecond)
+
+		result, err := cachedClient.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, goblin, result)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+
+		stats := cachedClient.Stats()[ResourceMonster]
+		assert.Equal(t, uint64(1), stats.StaleServed)
+		assert.Equal(t, uint64(1), stats.Errors)
+	})
+
+	t.Run("returns the error past the stale window", func(t *testing.T) {
+		_, cachedClient := newClient(10 * time.Millisecond)
+
+		cachedClient.GetMonster("goblin")
+		time.Sleep(30 * time.Millisecond)
+
+		result, err := cachedClient.GetMonster("goblin")
+		assert.Nil(t, result)
+		assert.Equal(t, upstreamErr, err)
+	})
+}
+
+func TestCachedClient_StaleWhileRevalidate(t *testing.T) {
+	goblin := &entities.Monster{Key: "goblin", Name: "Goblin"}
+	refreshed := &entities.Monster{Key: "goblin", Name: "Goblin", HitPoints: 8}
+
+	mockClient := new(MockClient)
+	mockClient.On("GetMonster", "goblin").Return(goblin, nil).Once()
+	mockClient.On("GetMonster", "goblin").Return(refreshed, nil)
+
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client:               mockClient,
+		TTL:                  10 * time.Millisecond,
+		StaleWhileRevalidate: true,
+		MaxStale:             time.Hour,
+	})
+	assert.NoError(t, err)
+
+	cachedClient.GetMonster("goblin")
+	time.Sleep(20 * time.Millisecond)
+
+	// the expired value is served right away while it is refreshed
+	result, err := cachedClient.GetMonster("goblin")
+	assert.NoError(t, err)
+	assert.Equal(t, goblin, result)
+
+	assert.Eventually(t, func() bool {
+		result, _ := cachedClient.GetMonster("goblin")
+		return result == refreshed
+	}, time.Second, 5*time.Millisecond)
+
+	assert.GreaterOrEqual(t, cachedClient.Stats()[ResourceMonster].StaleServed, uint64(1))
+}
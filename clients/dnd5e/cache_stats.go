@@ -0,0 +1,120 @@
+package dnd5e
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheEventType describes what happened to a cache entry
+type CacheEventType string
+
+const (
+	CacheEventHit          CacheEventType = "hit"
+	CacheEventMiss         CacheEventType = "miss"
+	CacheEventError        CacheEventType = "error"
+	CacheEventEviction     CacheEventType = "eviction"
+	CacheEventExpiration   CacheEventType = "expiration"
+	CacheEventTypeMismatch CacheEventType = "type_mismatch"
+	// CacheEventStale is an expired entry served while revalidating or in
+	// place of a failed fetch.
+	CacheEventStale CacheEventType = "stale"
+	// CacheEventNotFound is a lookup answered by a cached not-found result.
+	CacheEventNotFound CacheEventType = "not_found"
+)
+
+// CacheEvent is passed to CachedClientConfig.OnEvent for every cache lookup,
+// failed fetch, eviction and expiration.
+type CacheEvent struct {
+	Type CacheEventType
+	Kind ResourceKind
+	// Key is the cache key, including any rules version prefix such as "2024:"
+	Key string
+	// Err is the upstream error for CacheEventError events and the cached
+	// error for CacheEventNotFound events.
+	Err error
+}
+
+// CacheStats holds the counters for one resource kind. Entries and OldestEntryAge
+// describe the cache contents at the time Stats was called.
+type CacheStats struct {
+	Hits           uint64
+	Misses         uint64
+	Errors         uint64
+	Evictions      uint64
+	Expirations    uint64
+	TypeMismatches uint64
+	StaleServed    uint64
+	NotFoundHits   uint64
+	Entries        int
+	OldestEntryAge time.Duration
+}
+
+// cacheStats accumulates per-kind counters and forwards events to the hook
+type cacheStats struct {
+	mu      sync.Mutex
+	perKind map[ResourceKind]*CacheStats
+	onEvent func(CacheEvent)
+}
+
+func newCacheStats(onEvent func(CacheEvent)) *cacheStats {
+	return &cacheStats{
+		perKind: make(map[ResourceKind]*CacheStats),
+		onEvent: onEvent,
+	}
+}
+
+func (s *cacheStats) record(eventType CacheEventType, kind ResourceKind, key string, err error) {
+	s.mu.Lock()
+	stats, ok := s.perKind[kind]
+	if !ok {
+		stats = &CacheStats{}
+		s.perKind[kind] = stats
+	}
+
+	switch eventType {
+	case CacheEventHit:
+		stats.Hits++
+	case CacheEventMiss:
+		stats.Misses++
+	case CacheEventError:
+		stats.Errors++
+	case CacheEventEviction:
+		stats.Evictions++
+	case CacheEventExpiration:
+		stats.Expirations++
+	case CacheEventTypeMismatch:
+		stats.TypeMismatches++
+	case CacheEventStale:
+		stats.StaleServed++
+	case CacheEventNotFound:
+		stats.NotFoundHits++
+	}
+	s.mu.Unlock()
+
+	if s.onEvent != nil {
+		s.onEvent(CacheEvent{Type: eventType, Kind: kind, Key: key, Err: err})
+	}
+}
+
+// Stats returns a snapshot of the cache counters and contents per resource kind
+func (c *CachedClient) Stats() map[ResourceKind]CacheStats {
+	out := make(map[ResourceKind]CacheStats)
+
+	c.stats.mu.Lock()
+	for kind, stats := range c.stats.perKind {
+		out[kind] = *stats
+	}
+	c.stats.mu.Unlock()
+
+	now := time.Now()
+	c.cache.each(func(key string, entry *cacheEntry) {
+		stats := out[entry.kind]
+		stats.Entries++
+		if age := now.Sub(entry.timestamp); age > stats.OldestEntryAge {
+			stats.OldestEntryAge = age
+		}
+		out[entry.kind] = stats
+	})
+
+	return out
+}
@@ -0,0 +1,96 @@
+package dnd5e
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedClient_Stats(t *testing.T) {
+	mockClient := new(MockClient)
+
+	var mu sync.Mutex
+	var events []CacheEvent
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client:     mockClient,
+		TTL:        24 * time.Hour,
+		MaxEntries: 2,
+		OnEvent: func(event CacheEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		},
+	})
+	assert.NoError(t, err)
+
+	apiErr := errors.New("API error")
+	mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+	mockClient.On("GetMonster", "gobln").Return(nil, apiErr).Once()
+	mockClient.On("GetSpell", "shield").Return(&entities.Spell{Key: "shield"}, nil).Once()
+	mockClient.On("GetSpell", "light").Return(&entities.Spell{Key: "light"}, nil).Once()
+
+	cachedClient.GetMonster("goblin")
+	cachedClient.GetMonster("goblin")
+	cachedClient.GetMonster("gobln")
+	cachedClient.GetSpell("shield")
+	// evicts the goblin
+	cachedClient.GetSpell("light")
+
+	stats := cachedClient.Stats()
+
+	assert.Equal(t, uint64(1), stats[ResourceMonster].Hits)
+	assert.Equal(t, uint64(2), stats[ResourceMonster].Misses)
+	assert.Equal(t, uint64(1), stats[ResourceMonster].Errors)
+	assert.Equal(t, uint64(1), stats[ResourceMonster].Evictions)
+	assert.Equal(t, 0, stats[ResourceMonster].Entries)
+
+	assert.Equal(t, uint64(0), stats[ResourceSpell].Hits)
+	assert.Equal(t, uint64(2), stats[ResourceSpell].Misses)
+	assert.Equal(t, 2, stats[ResourceSpell].Entries)
+	assert.Greater(t, stats[ResourceSpell].OldestEntryAge, time.Duration(0))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 7, len(events))
+	assert.Equal(t, CacheEvent{Type: CacheEventMiss, Kind: ResourceMonster, Key: "monster:goblin"}, events[0])
+	assert.Equal(t, CacheEvent{Type: CacheEventHit, Kind: ResourceMonster, Key: "monster:goblin"}, events[1])
+	assert.Equal(t, CacheEvent{Type: CacheEventError, Kind: ResourceMonster, Key: "monster:gobln", Err: apiErr}, events[3])
+	assert.Equal(t, CacheEvent{Type: CacheEventEviction, Kind: ResourceMonster, Key: "monster:goblin"}, events[6])
+}
+
+func TestCachedClient_Stats_TypeMismatch(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
+
+	cachedClient.storeInCache(ResourceRace, "race:dwarf", "not a race")
+
+	dwarf := &entities.Race{Key: "dwarf"}
+	mockClient.On("GetRace", "dwarf").Return(dwarf, nil).Once()
+
+	race, err := cachedClient.GetRace("dwarf")
+	assert.NoError(t, err)
+	assert.Equal(t, dwarf, race)
+	assert.Equal(t, uint64(1), cachedClient.Stats()[ResourceRace].TypeMismatches)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCachedClient_Stats_Expiration(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient := NewCachedClient(mockClient, 10*time.Millisecond).(*CachedClient)
+
+	mockClient.On("GetRace", "dwarf").Return(&entities.Race{Key: "dwarf"}, nil).Twice()
+
+	cachedClient.GetRace("dwarf")
+	time.Sleep(20 * time.Millisecond)
+	cachedClient.GetRace("dwarf")
+
+	stats := cachedClient.Stats()[ResourceRace]
+	assert.Equal(t, uint64(1), stats.Expirations)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+}
@@ -0,0 +1,161 @@
+package dnd5e
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"sync"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+// CacheStore is a storage backend shared by CachedClient instances, e.g. a
+// Redis-like store in a multi-replica deployment. Values are opaque serialized
+// bytes; implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the value stored under key. found is false if the key is
+	// missing or its TTL has passed.
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores value under key for ttl. A ttl of zero or less means no expiry.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore is a map-backed CacheStore, useful for tests or as a reference
+// implementation. Expired keys are dropped when they are read.
+type MemoryStore struct {
+	mu    sync.Mutex
+	items map[string]memoryStoreItem
+}
+
+type memoryStoreItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		items: make(map[string]memoryStoreItem),
+	}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !item.expiresAt.IsZero() && time.Now().After(item.expiresAt) {
+		delete(s.items, key)
+		return nil, false, nil
+	}
+
+	return item.value, true, nil
+}
+
+func (s *MemoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := memoryStoreItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.items[key] = item
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+	return nil
+}
+
+// storedValue is the envelope a cache entry is serialized in for a CacheStore
+type storedValue struct {
+	Kind     ResourceKind
+	StoredAt time.Time
+	Value    interface{}
+}
+
+func init() {
+	// every value CachedClient caches, and every concrete type behind an
+	// interface inside them, has to be registered for gob to decode it
+	for _, v := range []interface{}{
+		[]*entities.ReferenceItem{},
+		&entities.Race{},
+		&entities.Equipment{},
+		&entities.Weapon{},
+		&entities.Armor{},
+		&entities.Class{},
+		&entities.Spell{},
+		&entities.Feature{},
+		&entities.Skill{},
+		&entities.Monster{},
+		&entities.Level{},
+		&entities.Proficiency{},
+		&entities.DamageType{},
+		&entities.EquipmentCategory{},
+		&entities.Background{},
+		&entities.ReferenceOption{},
+		&entities.CountedReferenceOption{},
+		&entities.ChoiceOption{},
+		&entities.MultipleOption{},
+		&entities.RangerSpecific{},
+		&entities.BarbarianSpecific{},
+		&entities.BardSpecific{},
+		&entities.ClericSpecific{},
+		&entities.DruidSpecific{},
+		&entities.FighterSpecific{},
+		&entities.MonkSpecific{},
+		&entities.PaladinSpecific{},
+		&entities.RogueSpecific{},
+		&entities.SorcererSpecific{},
+		&entities.WarlockSpecific{},
+		&entities.WizardSpecific{},
+	} {
+		gob.Register(v)
+	}
+}
+
+// encodeCacheEntry serializes entry for a CacheStore
+func encodeCacheEntry(entry *cacheEntry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(&storedValue{
+		Kind:     entry.kind,
+		StoredAt: entry.timestamp,
+		Value:    entry.data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeCacheEntry restores an entry written by encodeCacheEntry, keeping its
+// original timestamp so it expires as if it had never left memory
+func decodeCacheEntry(data []byte) (*cacheEntry, error) {
+	stored := &storedValue{}
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cacheEntry{
+		data:      stored.Value,
+		kind:      stored.Kind,
+		timestamp: stored.StoredAt,
+	}, nil
+}
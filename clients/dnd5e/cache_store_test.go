@@ -0,0 +1,147 @@
+package dnd5e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		_, found, err := store.Get(ctx, "monster:goblin")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		assert.NoError(t, store.Set(ctx, "monster:goblin", []byte("goblin"), time.Hour))
+
+		value, found, err := store.Get(ctx, "monster:goblin")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("goblin"), value)
+
+		assert.NoError(t, store.Delete(ctx, "monster:goblin"))
+		assert.NoError(t, store.Delete(ctx, "monster:goblin"))
+
+		_, found, _ = store.Get(ctx, "monster:goblin")
+		assert.False(t, found)
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		store.Set(ctx, "monster:goblin", []byte("goblin"), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found, err := store.Get(ctx, "monster:goblin")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestCacheEntryCodec(t *testing.T) {
+	t.Run("keeps concrete types behind interfaces", func(t *testing.T) {
+		entry := &cacheEntry{
+			data: &entities.Level{
+				Level:         3,
+				ClassSpecific: &entities.RangerSpecific{FavoredEnemies: 1},
+			},
+			kind:      ResourceClassLevel,
+			timestamp: time.Now().Add(-time.Minute),
+		}
+
+		data, err := encodeCacheEntry(entry)
+		assert.NoError(t, err)
+
+		decoded, err := decodeCacheEntry(data)
+		assert.NoError(t, err)
+		assert.Equal(t, ResourceClassLevel, decoded.kind)
+		assert.True(t, entry.timestamp.Equal(decoded.timestamp))
+		assert.Equal(t, entry.data, decoded.data)
+	})
+
+	t.Run("keeps the equipment type", func(t *testing.T) {
+		data, err := encodeCacheEntry(&cacheEntry{
+			data: &entities.Weapon{Key: "club", Name: "Club"},
+			kind: ResourceEquipment,
+		})
+		assert.NoError(t, err)
+
+		decoded, err := decodeCacheEntry(data)
+		assert.NoError(t, err)
+
+		weapon, ok := decoded.data.(EquipmentInterface)
+		assert.True(t, ok)
+		assert.Equal(t, "weapon", weapon.GetType())
+	})
+}
+
+func TestCachedClient_Store(t *testing.T) {
+	goblin := &entities.Monster{Key: "goblin", Name: "Goblin"}
+
+	t.Run("shares entries between clients", func(t *testing.T) {
+		store := NewMemoryStore()
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil)
+
+		first, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+		second, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+
+		result, err := first.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, goblin, result)
+
+		result, err = second.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, goblin, result)
+
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+		assert.Equal(t, uint64(1), second.Stats()[ResourceMonster].Hits)
+	})
+
+	t.Run("ignores entries older than the ttl", func(t *testing.T) {
+		store := NewMemoryStore()
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil)
+
+		data, err := encodeCacheEntry(&cacheEntry{
+			data:      goblin,
+			kind:      ResourceMonster,
+			timestamp: time.Now().Add(-2 * time.Hour),
+		})
+		assert.NoError(t, err)
+		store.Set(context.Background(), "monster:goblin", data, 0)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+
+		cachedClient.GetMonster("goblin")
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+	})
+
+	t.Run("invalidation deletes from the store", func(t *testing.T) {
+		store := NewMemoryStore()
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+
+		cachedClient.GetMonster("goblin")
+		assert.Equal(t, 1, cachedClient.InvalidateKind(ResourceMonster))
+
+		_, found, _ := store.Get(context.Background(), "monster:goblin")
+		assert.False(t, found)
+
+		cachedClient.GetMonster("goblin")
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+	})
+}
@@ -1,8 +1,10 @@
 package dnd5e
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"reflect"
 	"sync"
 	"time"
 
@@ -12,21 +14,226 @@ import (
 // cacheEntry holds cached data with timestamp
 type cacheEntry struct {
 	data      interface{}
+	kind      ResourceKind
 	timestamp time.Time
+	// err is set instead of data for a cached not-found result
+	err error
 }
 
 // CachedClient wraps the D&D 5e API client with an in-memory cache
 type CachedClient struct {
 	client Interface
-	cache  sync.Map
+	cache  *lruCache
 	ttl    time.Duration
+	stats  *cacheStats
+	// ttlByKind overrides ttl for individual resource kinds
+	ttlByKind map[ResourceKind]time.Duration
+	// copyOnRead hands every caller its own deep copy of cached values
+	copyOnRead bool
+	// store is an optional second tier shared with other clients
+	store CacheStore
+	// namespace prefixes every cache key with the rules version of client,
+	// e.g. "2024:", so entries of different versions never mix
+	namespace string
+	// logger receives store failures and other problems that aren't returned
+	logger Logger
+
+	// maxStale is how long entries are kept past ttl so they can be served
+	// while revalidating or when the upstream fails
+	maxStale             time.Duration
+	staleWhileRevalidate bool
+	staleIfError         bool
+
+	// notFoundTTL and notFoundTTLByKind control negative caching of 404s
+	notFoundTTL       time.Duration
+	notFoundTTLByKind map[ResourceKind]time.Duration
+
+	// flights coalesces concurrent misses for the same cache key
+	flights flightGroup
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// CachedClientConfig configures a CachedClient created with NewCachedClientWithConfig
+type CachedClientConfig struct {
+	Client Interface
+	// TTL is how long entries are fresh, unless overridden by TTLByKind
+	TTL time.Duration
+	// TTLByKind sets the TTL of individual resource kinds, e.g. a long one for
+	// ResourceMonster and a short one for ResourceBackground. Kinds that aren't
+	// listed use TTL. It covers both the lists and the items of a kind.
+	TTLByKind map[ResourceKind]time.Duration
+	// MaxEntries bounds the number of cached entries; the least recently used
+	// entries are evicted once it is exceeded. Zero means unbounded.
+	MaxEntries int
+	// MaxBytes is an approximate memory budget, measured by the JSON size of the
+	// cached values. Zero means unbounded.
+	MaxBytes int64
+	// CleanupInterval starts a background janitor that sweeps expired entries at
+	// this interval. Zero disables it; expired entries are then only dropped on read.
+	CleanupInterval time.Duration
+	// OnEvent, if set, is called synchronously for every cache event. It must be
+	// safe for concurrent use and should return quickly.
+	OnEvent func(CacheEvent)
+	// Store, if set, is a second cache tier behind the in-process cache. Misses
+	// are looked up there before calling the API, and fetched values are written
+	// to both. Nil keeps the cache in memory only.
+	Store CacheStore
+	// PersistDir keeps a copy of every cached value in files under this
+	// directory, so a restarted client starts warm. Entries are read back
+	// lazily on a miss and keep their original fetch time. It is shorthand for
+	// Store: NewFileStore(PersistDir) and can't be combined with Store.
+	PersistDir string
+	// StaleWhileRevalidate serves entries that expired less than MaxStale ago
+	// immediately and refreshes them in the background.
+	StaleWhileRevalidate bool
+	// StaleIfError serves entries that expired less than MaxStale ago when
+	// fetching a fresh value fails.
+	StaleIfError bool
+	// MaxStale is how long past TTL an entry may still be served. It is
+	// required by StaleWhileRevalidate and StaleIfError.
+	MaxStale time.Duration
+	// NotFoundTTL caches not-found (404) results for this long, so repeated
+	// lookups of keys that don't exist don't reach the API. Other errors are
+	// never cached. Zero disables it. Not-found results are kept in process
+	// only, never in Store, and are not served stale.
+	NotFoundTTL time.Duration
+	// NotFoundTTLByKind overrides NotFoundTTL per resource kind. A zero value
+	// disables negative caching for that kind.
+	NotFoundTTLByKind map[ResourceKind]time.Duration
+	// CopyOnRead returns a deep copy of the cached value to every caller, so
+	// callers can modify what they get without affecting each other or the
+	// cache. Without it all callers share the same pointers and must treat
+	// them as read-only.
+	CopyOnRead bool
+	// Logger receives failures that don't reach the caller, such as shared
+	// store errors and failed background refreshes. Nil discards them.
+	Logger Logger
 }
 
 // NewCachedClient creates a new cached client with specified TTL
 func NewCachedClient(client Interface, ttl time.Duration) Interface {
 	return &CachedClient{
-		client: client,
-		ttl:    ttl,
+		client:    client,
+		cache:     newLRUCache(0, 0),
+		ttl:       ttl,
+		stats:     newCacheStats(nil),
+		namespace: cacheNamespace(client),
+		stop:      make(chan struct{}),
+	}
+}
+
+// NewCachedClientWithConfig creates a cached client with bounded storage and an
+// optional background janitor. Call Close to stop the janitor.
+func NewCachedClientWithConfig(cfg *CachedClientConfig) (*CachedClient, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is required")
+	}
+
+	if cfg.Client == nil {
+		return nil, errors.New("cfg.Client is required")
+	}
+
+	if cfg.MaxEntries < 0 || cfg.MaxBytes < 0 {
+		return nil, errors.New("cfg.MaxEntries and cfg.MaxBytes must not be negative")
+	}
+
+	if cfg.MaxStale < 0 {
+		return nil, errors.New("cfg.MaxStale must not be negative")
+	}
+
+	if (cfg.StaleWhileRevalidate || cfg.StaleIfError) && cfg.MaxStale == 0 {
+		return nil, errors.New("cfg.MaxStale is required with cfg.StaleWhileRevalidate or cfg.StaleIfError")
+	}
+
+	ttlByKind := make(map[ResourceKind]time.Duration, len(cfg.TTLByKind))
+	for kind, ttl := range cfg.TTLByKind {
+		if ttl <= 0 {
+			return nil, fmt.Errorf("cfg.TTLByKind[%s] must be positive", kind)
+		}
+		ttlByKind[kind] = ttl
+	}
+
+	if cfg.NotFoundTTL < 0 {
+		return nil, errors.New("cfg.NotFoundTTL must not be negative")
+	}
+
+	notFoundTTLByKind := make(map[ResourceKind]time.Duration, len(cfg.NotFoundTTLByKind))
+	for kind, ttl := range cfg.NotFoundTTLByKind {
+		if ttl < 0 {
+			return nil, fmt.Errorf("cfg.NotFoundTTLByKind[%s] must not be negative", kind)
+		}
+		notFoundTTLByKind[kind] = ttl
+	}
+
+	store := cfg.Store
+	if cfg.PersistDir != "" {
+		if store != nil {
+			return nil, errors.New("cfg.Store and cfg.PersistDir are mutually exclusive")
+		}
+
+		fileStore, err := NewFileStore(cfg.PersistDir)
+		if err != nil {
+			return nil, fmt.Errorf("creating persistent cache: %w", err)
+		}
+		store = fileStore
+	}
+
+	c := &CachedClient{
+		client:    cfg.Client,
+		cache:     newLRUCache(cfg.MaxEntries, cfg.MaxBytes),
+		ttl:       cfg.TTL,
+		stats:     newCacheStats(cfg.OnEvent),
+		store:     store,
+		namespace: cacheNamespace(cfg.Client),
+		logger:    cfg.Logger,
+		stop:      make(chan struct{}),
+
+		ttlByKind:  ttlByKind,
+		copyOnRead: cfg.CopyOnRead,
+
+		staleWhileRevalidate: cfg.StaleWhileRevalidate,
+		staleIfError:         cfg.StaleIfError,
+
+		notFoundTTL:       cfg.NotFoundTTL,
+		notFoundTTLByKind: notFoundTTLByKind,
+	}
+
+	if c.staleWhileRevalidate || c.staleIfError {
+		c.maxStale = cfg.MaxStale
+	}
+
+	if cfg.CleanupInterval > 0 {
+		go c.janitor(cfg.CleanupInterval)
+	}
+
+	return c, nil
+}
+
+// Close stops the background janitor, if any. The client remains usable.
+func (c *CachedClient) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+
+	return nil
+}
+
+// janitor periodically removes expired entries until Close is called
+func (c *CachedClient) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			for _, item := range c.cache.removeExpired(c.retention) {
+				c.stats.record(CacheEventExpiration, item.entry.kind, item.key, nil)
+			}
+		}
 	}
 }
 
@@ -37,160 +244,324 @@ func (e *cacheEntry) isExpired(ttl time.Duration) bool {
 	return time.Since(e.timestamp) > ttl
 }
 
-// getFromCache attempts to retrieve and type-assert cached data
-func (c *CachedClient) getFromCache(key string) (interface{}, bool) {
-	if cached, ok := c.cache.Load(key); ok {
-		if entry, ok := cached.(*cacheEntry); ok && !entry.isExpired(c.ttl) {
-			return entry.data, true
+// ttlFor returns how long entry is fresh
+func (c *CachedClient) ttlFor(entry *cacheEntry) time.Duration {
+	if entry.err != nil {
+		return c.notFoundTTLFor(entry.kind)
+	}
+
+	if ttl, ok := c.ttlByKind[entry.kind]; ok {
+		return ttl
+	}
+
+	return c.ttl
+}
+
+// retention is how long an entry is kept: its TTL plus any stale window.
+// Not-found results are never served stale.
+func (c *CachedClient) retention(entry *cacheEntry) time.Duration {
+	if entry.err != nil {
+		return c.ttlFor(entry)
+	}
+
+	return c.ttlFor(entry) + c.maxStale
+}
+
+// notFoundTTLFor returns how long not-found results of kind are cached
+func (c *CachedClient) notFoundTTLFor(kind ResourceKind) time.Duration {
+	if ttl, ok := c.notFoundTTLByKind[kind]; ok {
+		return ttl
+	}
+
+	return c.notFoundTTL
+}
+
+// getFromCache attempts to retrieve a cached entry. The entry may be past its
+// TTL but still within the stale window.
+func (c *CachedClient) getFromCache(key string) (*cacheEntry, bool) {
+	if entry, ok := c.cache.get(key); ok {
+		if !entry.isExpired(c.retention(entry)) {
+			return entry, true
 		}
 		// Remove expired entry
-		c.cache.Delete(key)
+		if c.cache.remove(key) {
+			c.stats.record(CacheEventExpiration, entry.kind, key, nil)
+		}
 	}
 	return nil, false
 }
 
-// storeInCache stores data in the cache
-func (c *CachedClient) storeInCache(key string, data interface{}) {
-	c.cache.Store(key, &cacheEntry{
+// storeInCache stores data in the cache, evicting least recently used entries if it is full
+func (c *CachedClient) storeInCache(kind ResourceKind, key string, data interface{}) *cacheEntry {
+	entry := &cacheEntry{
 		data:      data,
+		kind:      kind,
 		timestamp: time.Now(),
-	})
+	}
+
+	c.addEntry(key, entry)
+	return entry
 }
 
-// ListRaces returns cached race list or fetches from API
-func (c *CachedClient) ListRaces() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:races"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
+// addEntry adds an entry to the in-process cache and records any evictions it causes
+func (c *CachedClient) addEntry(key string, entry *cacheEntry) {
+	evicted := c.cache.add(key, entry)
+
+	for _, item := range evicted {
+		c.stats.record(CacheEventEviction, item.entry.kind, item.key, nil)
 	}
-	
-	// Cache miss - fetch from API
-	races, err := c.client.ListRaces()
+}
+
+// contextClient returns the wrapped client's ContextInterface, falling back
+// to its plain methods if it doesn't implement one
+func (c *CachedClient) contextClient() ContextInterface {
+	return withContext(c.client)
+}
+
+// log returns the configured Logger, or one that discards everything
+func (c *CachedClient) log() Logger {
+	return loggerOrNop(c.logger)
+}
+
+// getFromStore looks key up in the shared store and, if a live entry is found,
+// copies it into the in-process cache. Store errors are logged and treated as misses.
+func (c *CachedClient) getFromStore(ctx context.Context, kind ResourceKind, key string) (*cacheEntry, bool) {
+	if c.store == nil {
+		return nil, false
+	}
+
+	value, found, err := c.store.Get(ctx, key)
 	if err != nil {
-		return nil, err
+		c.log().Warn("cache store get failed", "kind", kind, "cache_key", key, "error", err)
+		return nil, false
 	}
-	
-	c.storeInCache(cacheKey, races)
-	return races, nil
+	if !found {
+		return nil, false
+	}
+
+	entry, err := decodeCacheEntry(value)
+	if err != nil {
+		// most likely written by an incompatible version; drop it so it is refetched
+		c.log().Warn("cache store entry could not be decoded", "kind", kind, "cache_key", key, "error", err)
+		c.deleteFromStore(key)
+		return nil, false
+	}
+
+	if entry.isExpired(c.retention(entry)) {
+		return nil, false
+	}
+
+	c.addEntry(key, entry)
+	return entry, true
 }
 
-// GetRace returns cached race or fetches from API
-func (c *CachedClient) GetRace(key string) (*entities.Race, error) {
-	cacheKey := fmt.Sprintf("race:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Race); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Race, got %T", cacheKey, cached)
-		// Fall through to API call
+// writeToStore writes entry through to the shared store, if one is configured
+func (c *CachedClient) writeToStore(ctx context.Context, key string, entry *cacheEntry) {
+	if c.store == nil {
+		return
 	}
-	
-	// Cache miss - fetch from API
-	race, err := c.client.GetRace(key)
+
+	value, err := encodeCacheEntry(entry)
+	if err != nil {
+		c.log().Error("cache store entry could not be encoded", "kind", entry.kind, "cache_key", key, "error", err)
+		return
+	}
+
+	err = c.store.Set(ctx, key, value, c.retention(entry))
 	if err != nil {
-		return nil, err
+		c.log().Warn("cache store set failed", "kind", entry.kind, "cache_key", key, "error", err)
 	}
-	
-	c.storeInCache(cacheKey, race)
-	return race, nil
 }
 
-// ListEquipment returns cached equipment list or fetches from API
-func (c *CachedClient) ListEquipment() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:equipment"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
+// deleteFromStore removes keys from the shared store, if one is configured
+func (c *CachedClient) deleteFromStore(keys ...string) {
+	if c.store == nil {
+		return
 	}
-	
-	// Cache miss - fetch from API
-	equipment, err := c.client.ListEquipment()
-	if err != nil {
-		return nil, err
+
+	for _, key := range keys {
+		err := c.store.Delete(context.Background(), key)
+		if err != nil {
+			c.log().Warn("cache store delete failed", "cache_key", key, "error", err)
+		}
 	}
-	
-	c.storeInCache(cacheKey, equipment)
-	return equipment, nil
 }
 
-// GetEquipment returns cached equipment or fetches from API
-func (c *CachedClient) GetEquipment(key string) (EquipmentInterface, error) {
-	cacheKey := fmt.Sprintf("equipment:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(EquipmentInterface); ok {
-			return typedResult, nil
+// cached returns the value stored under cacheKey, falling back to the shared
+// store, or calls load on a miss and caches its result. Concurrent misses for
+// the same key share a single load, including its error. The load runs detached
+// from the callers' contexts, bounded by sharedFetchTimeout, so one caller
+// giving up doesn't fail the others; each caller stops waiting when its own
+// ctx is done and gets ctx.Err(). Errors are returned as-is; only not-found
+// results are cached, and only if NotFoundTTL is set for kind. Expired entries
+// within the stale window are served according to the StaleWhileRevalidate and
+// StaleIfError options. cacheKey is prefixed with the client's namespace.
+func cached[T any](ctx context.Context, c *CachedClient, kind ResourceKind, cacheKey string, load func(ctx context.Context) (T, error)) (T, error) {
+	cacheKey = c.namespace + cacheKey
+
+	fetch := func(ctx context.Context) (interface{}, error) {
+		result, err := load(ctx)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) && c.notFoundTTLFor(kind) > 0 {
+				c.addEntry(cacheKey, &cacheEntry{kind: kind, timestamp: time.Now(), err: err})
+			}
+			return nil, err
 		}
-		log.Printf("Cache type mismatch for key %s, expected EquipmentInterface, got %T", cacheKey, cached)
-		// Fall through to API call
+
+		entry := c.storeInCache(kind, cacheKey, result)
+		c.writeToStore(ctx, cacheKey, entry)
+		return result, nil
 	}
-	
-	// Cache miss - fetch from API
-	equipment, err := c.client.GetEquipment(key)
-	if err != nil {
-		return nil, err
+
+	entry, ok := c.getFromCache(cacheKey)
+	if !ok {
+		entry, ok = c.getFromStore(ctx, kind, cacheKey)
 	}
-	
-	c.storeInCache(cacheKey, equipment)
-	return equipment, nil
-}
 
-// ListClasses returns cached class list or fetches from API
-func (c *CachedClient) ListClasses() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:classes"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
+	if ok && entry.err != nil {
+		c.stats.record(CacheEventNotFound, kind, cacheKey, entry.err)
+		var zero T
+		return zero, entry.err
+	}
+
+	var stale T
+	var hasStale bool
+	if ok {
+		typedResult, typed := entry.data.(T)
+		switch {
+		case !typed:
+			c.log().Error("cache type mismatch", "kind", kind, "cache_key", cacheKey,
+				"expected", reflect.TypeOf((*T)(nil)).Elem().String(), "got", fmt.Sprintf("%T", entry.data))
+			c.stats.record(CacheEventTypeMismatch, kind, cacheKey, nil)
+			// Fall through to API call
+		case !entry.isExpired(c.ttlFor(entry)):
+			c.stats.record(CacheEventHit, kind, cacheKey, nil)
+			return copyResult(c, typedResult), nil
+		case c.staleWhileRevalidate:
+			c.stats.record(CacheEventStale, kind, cacheKey, nil)
+			c.revalidate(ctx, kind, cacheKey, fetch)
+			return copyResult(c, typedResult), nil
+		default:
+			stale, hasStale = typedResult, true
 		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
 	}
-	
+
 	// Cache miss - fetch from API
-	classes, err := c.client.ListClasses()
+	c.stats.record(CacheEventMiss, kind, cacheKey, nil)
+
+	// don't start a load nobody is waiting for
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	data, err, _ := c.flights.do(ctx, cacheKey, func() (interface{}, error) {
+		ctx, cancel := detach(ctx)
+		defer cancel()
+
+		return fetch(ctx)
+	})
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		// the caller gave up; the load carries on and fills the cache for others
+		var zero T
+		return zero, ctxErr
+	}
 	if err != nil {
-		return nil, err
+		c.stats.record(CacheEventError, kind, cacheKey, err)
+		// a not-found is an answer, not an outage, so it isn't papered over
+		if hasStale && c.staleIfError && !errors.Is(err, ErrNotFound) {
+			c.stats.record(CacheEventStale, kind, cacheKey, nil)
+			return copyResult(c, stale), nil
+		}
+
+		var zero T
+		return zero, err
 	}
-	
-	c.storeInCache(cacheKey, classes)
-	return classes, nil
+
+	// the value just fetched is shared with the cache and any coalesced callers
+	result, _ := data.(T)
+	return copyResult(c, result), nil
+}
+
+// ListRaces returns cached race list or fetches from API
+func (c *CachedClient) ListRaces() ([]*entities.ReferenceItem, error) {
+	return c.ListRacesContext(context.Background())
+}
+
+// ListRacesContext returns cached race list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceRace, "list:races", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListRacesContext(ctx)
+	})
+}
+
+// GetRace returns cached race or fetches from API
+func (c *CachedClient) GetRace(key string) (*entities.Race, error) {
+	return c.GetRaceContext(context.Background(), key)
+}
+
+// GetRaceContext returns cached race or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetRaceContext(ctx context.Context, key string) (*entities.Race, error) {
+	return cached(ctx, c, ResourceRace, fmt.Sprintf("race:%s", key), func(ctx context.Context) (*entities.Race, error) {
+		return c.contextClient().GetRaceContext(ctx, key)
+	})
+}
+
+// ListEquipment returns cached equipment list or fetches from API
+func (c *CachedClient) ListEquipment() ([]*entities.ReferenceItem, error) {
+	return c.ListEquipmentContext(context.Background())
+}
+
+// ListEquipmentContext returns cached equipment list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceEquipment, "list:equipment", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListEquipmentContext(ctx)
+	})
+}
+
+// GetEquipment returns cached equipment or fetches from API
+func (c *CachedClient) GetEquipment(key string) (EquipmentInterface, error) {
+	return c.GetEquipmentContext(context.Background(), key)
+}
+
+// GetEquipmentContext returns cached equipment or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetEquipmentContext(ctx context.Context, key string) (EquipmentInterface, error) {
+	return cached(ctx, c, ResourceEquipment, fmt.Sprintf("equipment:%s", key), func(ctx context.Context) (EquipmentInterface, error) {
+		return c.contextClient().GetEquipmentContext(ctx, key)
+	})
+}
+
+// ListClasses returns cached class list or fetches from API
+func (c *CachedClient) ListClasses() ([]*entities.ReferenceItem, error) {
+	return c.ListClassesContext(context.Background())
+}
+
+// ListClassesContext returns cached class list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceClass, "list:classes", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListClassesContext(ctx)
+	})
 }
 
 // GetClass returns cached class or fetches from API
 func (c *CachedClient) GetClass(key string) (*entities.Class, error) {
-	cacheKey := fmt.Sprintf("class:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Class); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Class, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	class, err := c.client.GetClass(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, class)
-	return class, nil
+	return c.GetClassContext(context.Background(), key)
+}
+
+// GetClassContext returns cached class or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetClassContext(ctx context.Context, key string) (*entities.Class, error) {
+	return cached(ctx, c, ResourceClass, fmt.Sprintf("class:%s", key), func(ctx context.Context) (*entities.Class, error) {
+		return c.contextClient().GetClassContext(ctx, key)
+	})
 }
 
 // ListSpells returns cached spell list or fetches from API
 func (c *CachedClient) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	return c.ListSpellsContext(context.Background(), input)
+}
+
+// ListSpellsContext returns cached spell list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListSpellsContext(ctx context.Context, input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
 	// Create unique cache key based on input parameters
 	var cacheKey string
 	if input == nil {
@@ -204,356 +575,194 @@ func (c *CachedClient) ListSpells(input *ListSpellsInput) ([]*entities.Reference
 	} else {
 		cacheKey = fmt.Sprintf("list:spells:class:%s:level:%d", input.Class, *input.Level)
 	}
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	spells, err := c.client.ListSpells(input)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, spells)
-	return spells, nil
+
+	return cached(ctx, c, ResourceSpell, cacheKey, func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListSpellsContext(ctx, input)
+	})
 }
 
 // GetSpell returns cached spell or fetches from API
 func (c *CachedClient) GetSpell(key string) (*entities.Spell, error) {
-	cacheKey := fmt.Sprintf("spell:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Spell); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Spell, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	spell, err := c.client.GetSpell(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, spell)
-	return spell, nil
+	return c.GetSpellContext(context.Background(), key)
+}
+
+// GetSpellContext returns cached spell or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetSpellContext(ctx context.Context, key string) (*entities.Spell, error) {
+	return cached(ctx, c, ResourceSpell, fmt.Sprintf("spell:%s", key), func(ctx context.Context) (*entities.Spell, error) {
+		return c.contextClient().GetSpellContext(ctx, key)
+	})
 }
 
 // ListFeatures returns cached feature list or fetches from API
 func (c *CachedClient) ListFeatures() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:features"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	features, err := c.client.ListFeatures()
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, features)
-	return features, nil
+	return c.ListFeaturesContext(context.Background())
+}
+
+// ListFeaturesContext returns cached feature list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceFeature, "list:features", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListFeaturesContext(ctx)
+	})
 }
 
 // GetFeature returns cached feature or fetches from API
 func (c *CachedClient) GetFeature(key string) (*entities.Feature, error) {
-	cacheKey := fmt.Sprintf("feature:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Feature); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Feature, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	feature, err := c.client.GetFeature(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, feature)
-	return feature, nil
+	return c.GetFeatureContext(context.Background(), key)
+}
+
+// GetFeatureContext returns cached feature or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error) {
+	return cached(ctx, c, ResourceFeature, fmt.Sprintf("feature:%s", key), func(ctx context.Context) (*entities.Feature, error) {
+		return c.contextClient().GetFeatureContext(ctx, key)
+	})
 }
 
 // ListSkills returns cached skill list or fetches from API
 func (c *CachedClient) ListSkills() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:skills"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	skills, err := c.client.ListSkills()
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, skills)
-	return skills, nil
+	return c.ListSkillsContext(context.Background())
+}
+
+// ListSkillsContext returns cached skill list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceSkill, "list:skills", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListSkillsContext(ctx)
+	})
 }
 
 // GetSkill returns cached skill or fetches from API
 func (c *CachedClient) GetSkill(key string) (*entities.Skill, error) {
-	cacheKey := fmt.Sprintf("skill:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Skill); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Skill, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	skill, err := c.client.GetSkill(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, skill)
-	return skill, nil
+	return c.GetSkillContext(context.Background(), key)
+}
+
+// GetSkillContext returns cached skill or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetSkillContext(ctx context.Context, key string) (*entities.Skill, error) {
+	return cached(ctx, c, ResourceSkill, fmt.Sprintf("skill:%s", key), func(ctx context.Context) (*entities.Skill, error) {
+		return c.contextClient().GetSkillContext(ctx, key)
+	})
 }
 
 // ListMonsters returns cached monster list or fetches from API
 func (c *CachedClient) ListMonsters() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:monsters:all"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	monsters, err := c.client.ListMonsters()
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, monsters)
-	return monsters, nil
+	return c.ListMonstersContext(context.Background())
+}
+
+// ListMonstersContext returns cached monster list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceMonster, "list:monsters:all", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListMonstersContext(ctx)
+	})
 }
 
 // ListMonstersWithFilter returns cached filtered monster list or fetches from API
 func (c *CachedClient) ListMonstersWithFilter(input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
-	var cacheKey string
+	return c.ListMonstersWithFilterContext(context.Background(), input)
+}
+
+// ListMonstersWithFilterContext returns cached filtered monster list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListMonstersWithFilterContext(ctx context.Context, input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
 	if input == nil || input.ChallengeRating == nil {
-		return c.ListMonsters()
-	}
-	
-	cacheKey = fmt.Sprintf("list:monsters:cr:%g", *input.ChallengeRating)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	monsters, err := c.client.ListMonstersWithFilter(input)
-	if err != nil {
-		return nil, err
+		return c.ListMonstersContext(ctx)
 	}
-	
-	c.storeInCache(cacheKey, monsters)
-	return monsters, nil
+
+	cacheKey := fmt.Sprintf("list:monsters:cr:%g", *input.ChallengeRating)
+
+	return cached(ctx, c, ResourceMonster, cacheKey, func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListMonstersWithFilterContext(ctx, input)
+	})
 }
 
 // GetMonster returns cached monster or fetches from API
 func (c *CachedClient) GetMonster(key string) (*entities.Monster, error) {
-	cacheKey := fmt.Sprintf("monster:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Monster); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Monster, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	monster, err := c.client.GetMonster(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, monster)
-	return monster, nil
+	return c.GetMonsterContext(context.Background(), key)
+}
+
+// GetMonsterContext returns cached monster or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
+	return cached(ctx, c, ResourceMonster, fmt.Sprintf("monster:%s", key), func(ctx context.Context) (*entities.Monster, error) {
+		return c.contextClient().GetMonsterContext(ctx, key)
+	})
 }
 
 // GetClassLevel returns cached class level or fetches from API
 func (c *CachedClient) GetClassLevel(key string, level int) (*entities.Level, error) {
-	cacheKey := fmt.Sprintf("class:%s:level:%d", key, level)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Level); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Level, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	classLevel, err := c.client.GetClassLevel(key, level)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, classLevel)
-	return classLevel, nil
+	return c.GetClassLevelContext(context.Background(), key, level)
+}
+
+// GetClassLevelContext returns cached class level or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error) {
+	return cached(ctx, c, ResourceClassLevel, fmt.Sprintf("class:%s:level:%d", key, level), func(ctx context.Context) (*entities.Level, error) {
+		return c.contextClient().GetClassLevelContext(ctx, key, level)
+	})
 }
 
 // GetProficiency returns cached proficiency or fetches from API
 func (c *CachedClient) GetProficiency(key string) (*entities.Proficiency, error) {
-	cacheKey := fmt.Sprintf("proficiency:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Proficiency); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Proficiency, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	proficiency, err := c.client.GetProficiency(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, proficiency)
-	return proficiency, nil
+	return c.GetProficiencyContext(context.Background(), key)
+}
+
+// GetProficiencyContext returns cached proficiency or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error) {
+	return cached(ctx, c, ResourceProficiency, fmt.Sprintf("proficiency:%s", key), func(ctx context.Context) (*entities.Proficiency, error) {
+		return c.contextClient().GetProficiencyContext(ctx, key)
+	})
 }
 
 // ListDamageTypes returns cached damage type list or fetches from API
 func (c *CachedClient) ListDamageTypes() ([]*entities.ReferenceItem, error) {
-	cacheKey := "list:damage-types"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	damageTypes, err := c.client.ListDamageTypes()
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, damageTypes)
-	return damageTypes, nil
+	return c.ListDamageTypesContext(context.Background())
+}
+
+// ListDamageTypesContext returns cached damage type list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceDamageType, "list:damage-types", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListDamageTypesContext(ctx)
+	})
 }
 
 // GetDamageType returns cached damage type or fetches from API
 func (c *CachedClient) GetDamageType(key string) (*entities.DamageType, error) {
-	cacheKey := fmt.Sprintf("damage-type:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.DamageType); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.DamageType, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	damageType, err := c.client.GetDamageType(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, damageType)
-	return damageType, nil
+	return c.GetDamageTypeContext(context.Background(), key)
+}
+
+// GetDamageTypeContext returns cached damage type or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error) {
+	return cached(ctx, c, ResourceDamageType, fmt.Sprintf("damage-type:%s", key), func(ctx context.Context) (*entities.DamageType, error) {
+		return c.contextClient().GetDamageTypeContext(ctx, key)
+	})
 }
 
 // GetEquipmentCategory returns cached equipment category or fetches from API
 func (c *CachedClient) GetEquipmentCategory(key string) (*entities.EquipmentCategory, error) {
-	cacheKey := fmt.Sprintf("equipment-category:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.EquipmentCategory); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.EquipmentCategory, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	category, err := c.client.GetEquipmentCategory(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, category)
-	return category, nil
+	return c.GetEquipmentCategoryContext(context.Background(), key)
+}
+
+// GetEquipmentCategoryContext returns cached equipment category or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error) {
+	return cached(ctx, c, ResourceEquipmentCategory, fmt.Sprintf("equipment-category:%s", key), func(ctx context.Context) (*entities.EquipmentCategory, error) {
+		return c.contextClient().GetEquipmentCategoryContext(ctx, key)
+	})
 }
 
 // ListBackgrounds returns cached backgrounds list or fetches from API
 func (c *CachedClient) ListBackgrounds() ([]*entities.ReferenceItem, error) {
-	cacheKey := "backgrounds"
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.([]*entities.ReferenceItem); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected []*entities.ReferenceItem, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	backgrounds, err := c.client.ListBackgrounds()
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, backgrounds)
-	return backgrounds, nil
+	return c.ListBackgroundsContext(context.Background())
+}
+
+// ListBackgroundsContext returns cached backgrounds list or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return cached(ctx, c, ResourceBackground, "list:backgrounds", func(ctx context.Context) ([]*entities.ReferenceItem, error) {
+		return c.contextClient().ListBackgroundsContext(ctx)
+	})
 }
 
 // GetBackground returns cached background or fetches from API
 func (c *CachedClient) GetBackground(key string) (*entities.Background, error) {
-	cacheKey := fmt.Sprintf("background:%s", key)
-	
-	if cached, ok := c.getFromCache(cacheKey); ok {
-		if typedResult, ok := cached.(*entities.Background); ok {
-			return typedResult, nil
-		}
-		log.Printf("Cache type mismatch for key %s, expected *entities.Background, got %T", cacheKey, cached)
-		// Fall through to API call
-	}
-	
-	// Cache miss - fetch from API
-	background, err := c.client.GetBackground(key)
-	if err != nil {
-		return nil, err
-	}
-	
-	c.storeInCache(cacheKey, background)
-	return background, nil
-}
\ No newline at end of file
+	return c.GetBackgroundContext(context.Background(), key)
+}
+
+// GetBackgroundContext returns cached background or fetches from API, passing ctx through on a cache miss
+func (c *CachedClient) GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error) {
+	return cached(ctx, c, ResourceBackground, fmt.Sprintf("background:%s", key), func(ctx context.Context) (*entities.Background, error) {
+		return c.contextClient().GetBackgroundContext(ctx, key)
+	})
+}
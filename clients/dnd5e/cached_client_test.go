@@ -1,6 +1,7 @@
 package dnd5e
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -191,6 +192,95 @@ func (m *MockClient) GetBackground(key string) (*entities.Background, error) {
 	return args.Get(0).(*entities.Background), args.Error(1)
 }
 
+// Context variants delegate to the plain methods so expectations are shared
+func (m *MockClient) ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListRaces()
+}
+
+func (m *MockClient) GetRaceContext(ctx context.Context, key string) (*entities.Race, error) {
+	return m.GetRace(key)
+}
+
+func (m *MockClient) ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListEquipment()
+}
+
+func (m *MockClient) GetEquipmentContext(ctx context.Context, key string) (EquipmentInterface, error) {
+	return m.GetEquipment(key)
+}
+
+func (m *MockClient) ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListClasses()
+}
+
+func (m *MockClient) GetClassContext(ctx context.Context, key string) (*entities.Class, error) {
+	return m.GetClass(key)
+}
+
+func (m *MockClient) ListSpellsContext(ctx context.Context, input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	return m.ListSpells(input)
+}
+
+func (m *MockClient) GetSpellContext(ctx context.Context, key string) (*entities.Spell, error) {
+	return m.GetSpell(key)
+}
+
+func (m *MockClient) ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListFeatures()
+}
+
+func (m *MockClient) GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error) {
+	return m.GetFeature(key)
+}
+
+func (m *MockClient) ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListSkills()
+}
+
+func (m *MockClient) GetSkillContext(ctx context.Context, key string) (*entities.Skill, error) {
+	return m.GetSkill(key)
+}
+
+func (m *MockClient) ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListMonsters()
+}
+
+func (m *MockClient) ListMonstersWithFilterContext(ctx context.Context, input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
+	return m.ListMonstersWithFilter(input)
+}
+
+func (m *MockClient) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
+	return m.GetMonster(key)
+}
+
+func (m *MockClient) GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error) {
+	return m.GetClassLevel(key, level)
+}
+
+func (m *MockClient) GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error) {
+	return m.GetProficiency(key)
+}
+
+func (m *MockClient) ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListDamageTypes()
+}
+
+func (m *MockClient) GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error) {
+	return m.GetDamageType(key)
+}
+
+func (m *MockClient) GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error) {
+	return m.GetEquipmentCategory(key)
+}
+
+func (m *MockClient) ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return m.ListBackgrounds()
+}
+
+func (m *MockClient) GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error) {
+	return m.GetBackground(key)
+}
+
 func TestCachedClient_GetRace_CacheHit(t *testing.T) {
 	mockClient := new(MockClient)
 	cachedClient := NewCachedClient(mockClient, 24*time.Hour).(*CachedClient)
@@ -385,4 +475,237 @@ func TestCachedClient_ListMonstersWithFilter(t *testing.T) {
 	assert.Equal(t, monsters1, result3)
 
 	mockClient.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestCachedClient_Context(t *testing.T) {
+	client, err := NewDND5eAPI(&DND5eAPIConfig{Client: &mockHTTPClient{}})
+	assert.NoError(t, err)
+
+	cachedClient := NewCachedClient(client, 24*time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	race, err := cachedClient.(ContextInterface).GetRaceContext(ctx, "dwarf")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, race)
+}
+
+func TestCachedClient_PreservesTypedErrors(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient := NewCachedClient(mockClient, 24*time.Hour)
+
+	notFound := &StatusError{StatusCode: 404, Kind: ResourceMonster, Key: "gobln"}
+	mockClient.On("GetMonster", "gobln").Return(nil, notFound).Once()
+
+	_, err := cachedClient.GetMonster("gobln")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, "gobln", statusErr.Key)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestNewCachedClientWithConfig(t *testing.T) {
+	t.Run("cfg is required", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(nil)
+		assert.EqualError(t, err, "cfg is required")
+	})
+
+	t.Run("cfg.Client is required", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{})
+		assert.EqualError(t, err, "cfg.Client is required")
+	})
+
+	t.Run("limits must not be negative", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{Client: new(MockClient), MaxEntries: -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("cfg.Store and cfg.PersistDir are mutually exclusive", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{Client: new(MockClient), Store: NewMemoryStore(), PersistDir: t.TempDir()})
+		assert.EqualError(t, err, "cfg.Store and cfg.PersistDir are mutually exclusive")
+	})
+}
+
+func TestCachedClient_MaxEntries(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client:     mockClient,
+		TTL:        24 * time.Hour,
+		MaxEntries: 2,
+	})
+	assert.NoError(t, err)
+	defer cachedClient.Close()
+
+	dwarf := &entities.Race{Key: "dwarf", Name: "Dwarf"}
+	elf := &entities.Race{Key: "elf", Name: "Elf"}
+	human := &entities.Race{Key: "human", Name: "Human"}
+
+	mockClient.On("GetRace", "dwarf").Return(dwarf, nil).Twice()
+	mockClient.On("GetRace", "elf").Return(elf, nil).Once()
+	mockClient.On("GetRace", "human").Return(human, nil).Once()
+
+	cachedClient.GetRace("dwarf")
+	cachedClient.GetRace("elf")
+	// evicts dwarf, the least recently used entry
+	cachedClient.GetRace("human")
+
+	// elf is still cached, dwarf has to be fetched again
+	cachedClient.GetRace("elf")
+	cachedClient.GetRace("dwarf")
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, 2, cachedClient.cache.len())
+}
+
+func TestCachedClient_Janitor(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client:          mockClient,
+		TTL:             20 * time.Millisecond,
+		CleanupInterval: 10 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer cachedClient.Close()
+
+	mockClient.On("GetRace", "dwarf").Return(&entities.Race{Key: "dwarf"}, nil).Once()
+
+	_, err = cachedClient.GetRace("dwarf")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cachedClient.cache.len())
+
+	assert.Eventually(t, func() bool {
+		return cachedClient.cache.len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, cachedClient.Close())
+	assert.NoError(t, cachedClient.Close())
+}
+
+func TestCachedClient_NotFoundTTL(t *testing.T) {
+	notFound := &StatusError{StatusCode: 404, Kind: ResourceMonster, Key: "gobbo"}
+
+	newClient := func(cfg *CachedClientConfig) (*MockClient, *CachedClient) {
+		mockClient := new(MockClient)
+		cfg.Client = mockClient
+		cfg.TTL = time.Hour
+
+		cachedClient, err := NewCachedClientWithConfig(cfg)
+		assert.NoError(t, err)
+
+		return mockClient, cachedClient
+	}
+
+	t.Run("caches not-found results", func(t *testing.T) {
+		mockClient, cachedClient := newClient(&CachedClientConfig{NotFoundTTL: time.Minute})
+		mockClient.On("GetMonster", "gobbo").Return(nil, notFound)
+
+		for i := 0; i < 3; i++ {
+			result, err := cachedClient.GetMonster("gobbo")
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+		assert.Equal(t, uint64(2), cachedClient.Stats()[ResourceMonster].NotFoundHits)
+	})
+
+	t.Run("not-found results expire", func(t *testing.T) {
+		mockClient, cachedClient := newClient(&CachedClientConfig{NotFoundTTL: 10 * time.Millisecond})
+		mockClient.On("GetMonster", "gobbo").Return(nil, notFound)
+
+		cachedClient.GetMonster("gobbo")
+		time.Sleep(20 * time.Millisecond)
+		cachedClient.GetMonster("gobbo")
+
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+	})
+
+	t.Run("other errors are not cached", func(t *testing.T) {
+		mockClient, cachedClient := newClient(&CachedClientConfig{NotFoundTTL: time.Minute})
+		mockClient.On("GetMonster", "goblin").Return(nil, &StatusError{StatusCode: 503})
+
+		cachedClient.GetMonster("goblin")
+		cachedClient.GetMonster("goblin")
+
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+	})
+
+	t.Run("per kind override", func(t *testing.T) {
+		mockClient, cachedClient := newClient(&CachedClientConfig{
+			NotFoundTTL:       time.Minute,
+			NotFoundTTLByKind: map[ResourceKind]time.Duration{ResourceSpell: 0},
+		})
+		mockClient.On("GetMonster", "gobbo").Return(nil, notFound)
+		mockClient.On("GetSpell", "firebal").Return(nil, &StatusError{StatusCode: 404})
+
+		cachedClient.GetMonster("gobbo")
+		cachedClient.GetMonster("gobbo")
+		cachedClient.GetSpell("firebal")
+		cachedClient.GetSpell("firebal")
+
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+		mockClient.AssertNumberOfCalls(t, "GetSpell", 2)
+	})
+
+	t.Run("not-found replaces a stale value", func(t *testing.T) {
+		mockClient, cachedClient := newClient(&CachedClientConfig{
+			NotFoundTTL:  time.Minute,
+			StaleIfError: true,
+			MaxStale:     time.Hour,
+		})
+		cachedClient.ttl = 10 * time.Millisecond
+		mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+		mockClient.On("GetMonster", "goblin").Return(nil, notFound)
+
+		cachedClient.GetMonster("goblin")
+		time.Sleep(20 * time.Millisecond)
+
+		_, err := cachedClient.GetMonster("goblin")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = cachedClient.GetMonster("goblin")
+		assert.ErrorIs(t, err, ErrNotFound)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 2)
+	})
+
+	t.Run("ttl must not be negative", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{
+			Client:            new(MockClient),
+			NotFoundTTLByKind: map[ResourceKind]time.Duration{ResourceSpell: -1},
+		})
+		assert.EqualError(t, err, "cfg.NotFoundTTLByKind[spell] must not be negative")
+	})
+}
+
+func TestCachedClient_TTLByKind(t *testing.T) {
+	mockClient := new(MockClient)
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client:    mockClient,
+		TTL:       10 * time.Millisecond,
+		TTLByKind: map[ResourceKind]time.Duration{ResourceMonster: time.Hour},
+	})
+	assert.NoError(t, err)
+
+	mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil)
+	mockClient.On("GetSpell", "fireball").Return(&entities.Spell{Key: "fireball"}, nil)
+
+	cachedClient.GetMonster("goblin")
+	cachedClient.GetSpell("fireball")
+	time.Sleep(20 * time.Millisecond)
+	cachedClient.GetMonster("goblin")
+	cachedClient.GetSpell("fireball")
+
+	// monsters use their own TTL, spells fall back to the default
+	mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+	mockClient.AssertNumberOfCalls(t, "GetSpell", 2)
+
+	t.Run("ttl must be positive", func(t *testing.T) {
+		_, err := NewCachedClientWithConfig(&CachedClientConfig{
+			Client:    new(MockClient),
+			TTLByKind: map[ResourceKind]time.Duration{ResourceSpell: 0},
+		})
+		assert.EqualError(t, err, "cfg.TTLByKind[spell] must be positive")
+	})
+}
@@ -82,6 +82,11 @@ func urlToType(url string) string {
 		return ""
 	}
 
+	// Versioned URLs look like /api/2024/species/dwarf
+	if v := RulesVersion(urlparts[2]); (v == Rules2014 || v == Rules2024) && len(urlparts) > 3 {
+		return urlparts[3]
+	}
+
 	return urlparts[2]
 }
 
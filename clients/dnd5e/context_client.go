@@ -0,0 +1,199 @@
+package dnd5e
+
+import (
+	"context"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+// withContext returns client's ContextInterface, or, for clients that only
+// implement Interface, one that checks ctx before calling the plain methods
+func withContext(client Interface) ContextInterface {
+	if c, ok := client.(ContextInterface); ok {
+		return c
+	}
+
+	return plainContextClient{client}
+}
+
+// plainContextClient adapts an Interface without context support to
+// ContextInterface. A lookup that has started can't be cancelled.
+type plainContextClient struct {
+	client Interface
+}
+
+func (c plainContextClient) ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListRaces()
+}
+
+func (c plainContextClient) GetRaceContext(ctx context.Context, key string) (*entities.Race, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetRace(key)
+}
+
+func (c plainContextClient) ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListEquipment()
+}
+
+func (c plainContextClient) GetEquipmentContext(ctx context.Context, key string) (EquipmentInterface, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetEquipment(key)
+}
+
+func (c plainContextClient) ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListClasses()
+}
+
+func (c plainContextClient) GetClassContext(ctx context.Context, key string) (*entities.Class, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetClass(key)
+}
+
+func (c plainContextClient) ListSpellsContext(ctx context.Context, input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListSpells(input)
+}
+
+func (c plainContextClient) GetSpellContext(ctx context.Context, key string) (*entities.Spell, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetSpell(key)
+}
+
+func (c plainContextClient) ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListFeatures()
+}
+
+func (c plainContextClient) GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetFeature(key)
+}
+
+func (c plainContextClient) ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListSkills()
+}
+
+func (c plainContextClient) GetSkillContext(ctx context.Context, key string) (*entities.Skill, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetSkill(key)
+}
+
+func (c plainContextClient) ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListMonsters()
+}
+
+func (c plainContextClient) ListMonstersWithFilterContext(ctx context.Context, input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListMonstersWithFilter(input)
+}
+
+func (c plainContextClient) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetMonster(key)
+}
+
+func (c plainContextClient) GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetClassLevel(key, level)
+}
+
+func (c plainContextClient) GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetProficiency(key)
+}
+
+func (c plainContextClient) ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListDamageTypes()
+}
+
+func (c plainContextClient) GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetDamageType(key)
+}
+
+func (c plainContextClient) GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetEquipmentCategory(key)
+}
+
+func (c plainContextClient) ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.ListBackgrounds()
+}
+
+func (c plainContextClient) GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetBackground(key)
+}
@@ -0,0 +1,64 @@
+package dnd5e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// plainClient hides the context methods of the client it wraps, like an
+// implementation of Interface written without them
+type plainClient struct {
+	Interface
+}
+
+func TestWithContext(t *testing.T) {
+	mockClient := &MockClient{}
+	assert.Same(t, mockClient, withContext(mockClient))
+
+	client := withContext(plainClient{mockClient})
+	assert.IsType(t, plainContextClient{}, client)
+
+	t.Run("calls the plain method", func(t *testing.T) {
+		mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+
+		monster, err := client.GetMonsterContext(context.Background(), "goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "goblin", monster.Key)
+	})
+
+	t.Run("checks ctx first", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.GetMonsterContext(ctx, "goblin")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlainClient(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSpell", "fireball").Return(&entities.Spell{Key: "fireball"}, nil).Once()
+
+	cachedClient := NewCachedClient(plainClient{mockClient}, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		spell, err := cachedClient.GetSpell("fireball")
+		assert.NoError(t, err)
+		assert.Equal(t, "fireball", spell.Key)
+	}
+
+	// Resolver goes through the plain methods too
+	mockClient.On("GetSpell", "fireball").Return(&entities.Spell{Key: "fireball"}, nil).Once()
+
+	resolved, err := NewResolver(plainClient{mockClient}).Resolve(context.Background(), &entities.ReferenceItem{Key: "fireball", Type: "spells"})
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.Spell{Key: "fireball"}, resolved)
+
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package dnd5e
+
+import (
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+// cloneValue returns a deep copy of a value returned by Interface. Values of
+// other types are returned as-is.
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []*entities.ReferenceItem:
+		if v == nil {
+			return v
+		}
+		out := make([]*entities.ReferenceItem, len(v))
+		for i, item := range v {
+			out[i] = item.Clone()
+		}
+		return out
+	case *entities.Race:
+		return v.Clone()
+	case *entities.Equipment:
+		return v.Clone()
+	case *entities.Weapon:
+		return v.Clone()
+	case *entities.Armor:
+		return v.Clone()
+	case *entities.Class:
+		return v.Clone()
+	case *entities.Spell:
+		return v.Clone()
+	case *entities.Feature:
+		return v.Clone()
+	case *entities.Skill:
+		return v.Clone()
+	case *entities.Monster:
+		return v.Clone()
+	case *entities.Level:
+		return v.Clone()
+	case *entities.Proficiency:
+		return v.Clone()
+	case *entities.DamageType:
+		return v.Clone()
+	case *entities.EquipmentCategory:
+		return v.Clone()
+	case *entities.Background:
+		return v.Clone()
+	default:
+		return v
+	}
+}
+
+// copyResult returns a copy of result if the client hands out copies
+func copyResult[T any](c *CachedClient, result T) T {
+	if !c.copyOnRead {
+		return result
+	}
+
+	copied, ok := cloneValue(result).(T)
+	if !ok {
+		return result
+	}
+
+	return copied
+}
@@ -0,0 +1,149 @@
+package dnd5e
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMonster() *entities.Monster {
+	return &entities.Monster{
+		Key:              "goblin",
+		Name:             "Goblin",
+		HitPoints:        7,
+		Speed:            &entities.Speed{Walk: "30 ft."},
+		Proficiencies:    []*entities.MonsterProficiency{{Value: 6, Proficiency: &entities.ReferenceItem{Key: "skill-stealth"}}},
+		DamageImmunities: []string{},
+		MonsterActions: []*entities.MonsterAction{{
+			Name:   "Scimitar",
+			Damage: []*entities.Damage{{DamageDice: "1d6+2", DamageType: &entities.ReferenceItem{Key: "slashing"}}},
+		}},
+	}
+}
+
+func TestCloneValue(t *testing.T) {
+	t.Run("monster", func(t *testing.T) {
+		original := testMonster()
+		clone := cloneValue(original).(*entities.Monster)
+		assert.Equal(t, original, clone)
+
+		clone.HitPoints = 1
+		clone.Speed.Walk = "0 ft."
+		clone.Proficiencies[0].Proficiency.Key = "skill-athletics"
+		clone.MonsterActions[0].Damage[0].DamageType.Key = "fire"
+
+		assert.Equal(t, testMonster(), original)
+	})
+
+	t.Run("class with nested options", func(t *testing.T) {
+		newClass := func() *entities.Class {
+			return &entities.Class{
+				Key: "fighter",
+				StartingEquipmentOptions: []*entities.ChoiceOption{{
+					ChoiceCount: 1,
+					OptionList: &entities.OptionList{Options: []entities.Option{
+						&entities.MultipleOption{Items: []entities.Option{
+							&entities.CountedReferenceOption{Count: 20, Reference: &entities.ReferenceItem{Key: "arrow"}},
+						}},
+						&entities.ReferenceOption{Reference: &entities.ReferenceItem{Key: "chain-mail"}},
+					}},
+				}},
+			}
+		}
+
+		original := newClass()
+		clone := cloneValue(original).(*entities.Class)
+		assert.Equal(t, original, clone)
+
+		options := clone.StartingEquipmentOptions[0].OptionList.Options
+		options[0].(*entities.MultipleOption).Items[0].(*entities.CountedReferenceOption).Count = 1
+		options[1].(*entities.ReferenceOption).Reference.Key = "leather-armor"
+
+		assert.Equal(t, newClass(), original)
+	})
+
+	t.Run("level with class specifics", func(t *testing.T) {
+		original := &entities.Level{
+			Level:         5,
+			ClassSpecific: &entities.MonkSpecific{MartialArts: &entities.MartialArts{DiceCount: 1, DiceValue: 6}},
+		}
+		clone := cloneValue(original).(*entities.Level)
+		assert.Equal(t, original, clone)
+
+		clone.ClassSpecific.(*entities.MonkSpecific).MartialArts.DiceValue = 8
+		assert.Equal(t, 6, original.ClassSpecific.(*entities.MonkSpecific).MartialArts.DiceValue)
+	})
+
+	t.Run("equipment keeps its type", func(t *testing.T) {
+		var original EquipmentInterface = &entities.Weapon{Key: "club", Damage: &entities.Damage{DamageDice: "1d4"}}
+		clone := cloneValue(original).(EquipmentInterface)
+		assert.Equal(t, original, clone)
+
+		clone.(*entities.Weapon).Damage.DamageDice = "1d6"
+		assert.Equal(t, "1d4", original.(*entities.Weapon).Damage.DamageDice)
+	})
+
+	t.Run("reference lists", func(t *testing.T) {
+		original := []*entities.ReferenceItem{{Key: "dwarf"}}
+		clone := cloneValue(original).([]*entities.ReferenceItem)
+
+		clone[0].Key = "elf"
+		assert.Equal(t, "dwarf", original[0].Key)
+	})
+
+	t.Run("nil stays nil", func(t *testing.T) {
+		assert.Nil(t, cloneValue((*entities.Monster)(nil)).(*entities.Monster))
+		assert.Nil(t, cloneValue([]*entities.ReferenceItem(nil)).([]*entities.ReferenceItem))
+	})
+}
+
+func TestCachedClient_CopyOnRead(t *testing.T) {
+	t.Run("callers get isolated copies", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(testMonster(), nil)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+			Client:     mockClient,
+			TTL:        time.Hour,
+			CopyOnRead: true,
+		})
+		assert.NoError(t, err)
+
+		// run with -race: every caller modifies its own copy concurrently
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				monster, err := cachedClient.GetMonster("goblin")
+				assert.NoError(t, err)
+
+				monster.HitPoints -= i
+				monster.Speed.Walk = "0 ft."
+				monster.MonsterActions[0].Damage[0].DamageDice = "1d4"
+				monster.DamageImmunities = append(monster.DamageImmunities, "fire")
+			}(i)
+		}
+		wg.Wait()
+
+		monster, err := cachedClient.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, testMonster(), monster)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(testMonster(), nil)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour})
+		assert.NoError(t, err)
+
+		first, _ := cachedClient.GetMonster("goblin")
+		second, _ := cachedClient.GetMonster("goblin")
+		assert.Same(t, first, second)
+	})
+}
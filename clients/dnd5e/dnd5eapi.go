@@ -1,15 +1,16 @@
 package dnd5e
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fadedpez/dnd5e-api/entities"
 )
@@ -22,12 +23,33 @@ const (
 type dnd5eAPI struct {
 	client  httpIface
 	baseURL string
-	mu      sync.RWMutex
+	retry   *RetryPolicy
+	limiter *RateLimiter
+	rules   RulesVersion
+	// middleware wraps every request attempt; see DND5eAPIConfig.Middleware
+	middleware []Middleware
+	logger     Logger
+	mu         sync.RWMutex
 }
 
 type DND5eAPIConfig struct {
 	Client  httpIface
 	BaseURL string
+	// Retry is applied to every request; nil disables retries.
+	Retry *RetryPolicy
+	// RateLimiter throttles every outgoing request, including retries; nil disables it.
+	RateLimiter *RateLimiter
+	// RulesVersion appends the rules version to BaseURL, e.g. /api/2024/. Empty
+	// keeps the unversioned /api/ routes, which serve the 2014 rules.
+	RulesVersion RulesVersion
+	// Middleware wraps every request attempt, including retries, in the order
+	// given: the first sees the request first and the response last. Headers
+	// set by middleware are only sent when Client implements
+	// Do(*http.Request), as *http.Client does.
+	Middleware []Middleware
+	// Logger receives a debug message for every request attempt and a warning
+	// for every retry. Nil discards them.
+	Logger Logger
 }
 
 func NewDND5eAPI(cfg *DND5eAPIConfig) (Interface, error) {
@@ -39,14 +61,27 @@ func NewDND5eAPI(cfg *DND5eAPIConfig) (Interface, error) {
 		return nil, errors.New("cfg.Client is required")
 	}
 
+	if !cfg.RulesVersion.valid() {
+		return nil, fmt.Errorf("cfg.RulesVersion %q is not supported", cfg.RulesVersion)
+	}
+
 	baseURL := cfg.BaseURL
 	if baseURL == "" {
 		baseURL = baserulzURL
 	}
 
+	if cfg.RulesVersion != "" {
+		baseURL = strings.TrimSuffix(baseURL, "/") + "/" + string(cfg.RulesVersion) + "/"
+	}
+
 	return &dnd5eAPI{
-		client:  cfg.Client,
-		baseURL: baseURL,
+		client:     cfg.Client,
+		baseURL:    baseURL,
+		retry:      cfg.Retry,
+		limiter:    cfg.RateLimiter,
+		rules:      cfg.RulesVersion,
+		middleware: cfg.Middleware,
+		logger:     cfg.Logger,
 	}, nil
 }
 
@@ -57,23 +92,122 @@ func (c *dnd5eAPI) getBaseURL() string {
 }
 
 // newHTTPStatusError creates a standardized error for unexpected HTTP status codes
-func newHTTPStatusError(statusCode int) error {
-	return fmt.Errorf("unexpected status code: %d", statusCode)
+func newHTTPStatusError(statusCode int, kind ResourceKind, key, url string) error {
+	return &StatusError{
+		StatusCode: statusCode,
+		URL:        url,
+		Kind:       kind,
+		Key:        key,
+	}
 }
 
-func (c *dnd5eAPI) ListRaces() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "races")
-	if err != nil {
+// newDecodeError wraps a failure to decode the response body for url
+func newDecodeError(kind ResourceKind, key, url string, err error) error {
+	return &DecodeError{
+		URL:  url,
+		Kind: kind,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+// do sends req through the middleware chain once the rate limiter allows it
+func (c *dnd5eAPI) do(req *Request) (*http.Response, error) {
+	if err := c.limiter.Wait(req.HTTP.Context()); err != nil {
 		return nil, err
 	}
+
+	start := time.Now()
+	resp, err := chainMiddleware(c.middleware, c.roundTrip)(req)
+
+	args := []any{"method", req.HTTP.Method, "url", req.HTTP.URL.String(), "kind", req.Kind, "key", req.Key,
+		"attempt", req.Attempt, "duration", time.Since(start)}
+	if resp != nil {
+		args = append(args, "status", resp.StatusCode)
+	}
+	if err != nil {
+		args = append(args, "error", err)
+	}
+	c.log().Debug("dnd5e api request", args...)
+
+	return resp, err
+}
+
+// log returns the configured Logger, or one that discards everything
+func (c *dnd5eAPI) log() Logger {
+	return loggerOrNop(c.logger)
+}
+
+// get requests url and returns the response if the API answered with 200 OK,
+// retrying transient failures according to the configured RetryPolicy.
+// Any other status is reported as a *StatusError for the given resource.
+func (c *dnd5eAPI) get(ctx context.Context, kind ResourceKind, key, url string) (*http.Response, error) {
+	return c.send(ctx, kind, key, url, func(attempt int) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.do(&Request{HTTP: req, Kind: kind, Key: key, Attempt: attempt})
+	})
+}
+
+// send calls request until it succeeds with 200 OK or the RetryPolicy gives up
+func (c *dnd5eAPI) send(ctx context.Context, kind ResourceKind, key, url string, request func(attempt int) (*http.Response, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := request(attempt)
+		if err == nil && resp.StatusCode == httpStatusOK {
+			return resp, nil
+		}
+
+		if !c.retry.shouldRetry(attempt, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+
+			resp.Body.Close()
+			return nil, newHTTPStatusError(resp.StatusCode, kind, key, url)
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+
+		args := []any{"url", url, "kind", kind, "key", key, "attempt", attempt, "wait", wait}
+		if resp != nil {
+			args = append(args, "status", resp.StatusCode)
+			resp.Body.Close()
+		}
+		if err != nil {
+			args = append(args, "error", err)
+		}
+		c.log().Warn("retrying dnd5e api request", args...)
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// getJSON requests url and decodes the response body into out
+func (c *dnd5eAPI) getJSON(ctx context.Context, kind ResourceKind, key, url string, out interface{}) error {
+	resp, err := c.get(ctx, kind, key, url)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return newDecodeError(kind, key, url, err)
 	}
+
+	return nil
+}
+
+// getList requests a list endpoint and converts its results to reference items
+func (c *dnd5eAPI) getList(ctx context.Context, kind ResourceKind, url string) ([]*entities.ReferenceItem, error) {
 	response := listResponse{}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := c.getJSON(ctx, kind, "", url, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -86,19 +220,22 @@ func (c *dnd5eAPI) ListRaces() ([]*entities.ReferenceItem, error) {
 	return out, nil
 }
 
+func (c *dnd5eAPI) ListRaces() ([]*entities.ReferenceItem, error) {
+	return c.ListRacesContext(context.Background())
+}
+
+func (c *dnd5eAPI) ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceRace, c.getBaseURL()+c.racesPath())
+}
+
 func (c *dnd5eAPI) GetRace(key string) (*entities.Race, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "races/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetRaceContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetRaceContext(ctx context.Context, key string) (*entities.Race, error) {
 	response := raceResult{}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := c.getJSON(ctx, ResourceRace, key, c.getBaseURL()+c.racesPath()+"/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -109,10 +246,11 @@ func (c *dnd5eAPI) GetRace(key string) (*entities.Race, error) {
 		Speed:                      response.Speed,
 		Size:                       response.Size,
 		SizeDescription:            response.SizeDescription,
+		CreatureType:               response.Type,
 		AbilityBonuses:             abilityBonusResultsToAbilityBonuses(response.AbilityBonus),
 		Languages:                  referenceItemsToReferenceItems(response.Language),
 		Traits:                     referenceItemsToReferenceItems(response.Trait),
-		SubRaces:                   referenceItemsToReferenceItems(response.SubRaces),
+		SubRaces:                   referenceItemsToReferenceItems(response.getSubRaces()),
 		StartingProficiencies:      referenceItemsToReferenceItems(response.StartingProficiencies),
 		StartingProficiencyOptions: choiceResultToChoice(response.StartingProficiencyOptions),
 		LanguageOptions:            choiceResultToChoice(response.LanguageOptions),
@@ -122,43 +260,17 @@ func (c *dnd5eAPI) GetRace(key string) (*entities.Race, error) {
 }
 
 func (c *dnd5eAPI) ListEquipment() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "equipment")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
-
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
-
-	return out, nil
+	return c.ListEquipmentContext(context.Background())
 }
 
-func (c *dnd5eAPI) listEquipmentByCategory(category string) ([]*referenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "equipment-categories/" + category)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (c *dnd5eAPI) ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceEquipment, c.getBaseURL()+"equipment")
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) listEquipmentByCategory(ctx context.Context, category string) ([]*referenceItem, error) {
 	response := equipmentListResponse{}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := c.getJSON(ctx, ResourceEquipmentCategory, category, c.getBaseURL()+"equipment-categories/"+category, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -167,25 +279,28 @@ func (c *dnd5eAPI) listEquipmentByCategory(category string) ([]*referenceItem, e
 }
 
 func (c *dnd5eAPI) GetEquipment(key string) (EquipmentInterface, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "equipment/" + key)
+	return c.GetEquipmentContext(context.Background(), key)
+}
+
+func (c *dnd5eAPI) GetEquipmentContext(ctx context.Context, key string) (EquipmentInterface, error) {
+	url := c.getBaseURL() + "equipment/" + key
+
+	resp, err := c.get(ctx, ResourceEquipment, key, url)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := equipmentResult{}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
+	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	response := equipmentResult{}
+
 	err = json.Unmarshal(responseBody, &response)
 	if err != nil {
-		return nil, err
+		return nil, newDecodeError(ResourceEquipment, key, url, err)
 	}
 
 	switch response.getCategoryKey() {
@@ -194,7 +309,7 @@ func (c *dnd5eAPI) GetEquipment(key string) (EquipmentInterface, error) {
 
 		err = json.Unmarshal(responseBody, &weaponResponse)
 		if err != nil {
-			return nil, err
+			return nil, newDecodeError(ResourceEquipment, key, url, err)
 		}
 
 		return weaponResultToWeapon(weaponResponse), nil
@@ -204,7 +319,7 @@ func (c *dnd5eAPI) GetEquipment(key string) (EquipmentInterface, error) {
 
 		err = json.Unmarshal(responseBody, &armorResponse)
 		if err != nil {
-			return nil, err
+			return nil, newDecodeError(ResourceEquipment, key, url, err)
 		}
 
 		return armorResultToArmor(armorResponse), nil
@@ -215,87 +330,37 @@ func (c *dnd5eAPI) GetEquipment(key string) (EquipmentInterface, error) {
 }
 
 func (c *dnd5eAPI) ListClasses() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "classes")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
+	return c.ListClassesContext(context.Background())
+}
 
-	return out, nil
+func (c *dnd5eAPI) ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceClass, c.getBaseURL()+"classes")
 }
 
 func (c *dnd5eAPI) GetClass(key string) (*entities.Class, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "classes/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetClassContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetClassContext(ctx context.Context, key string) (*entities.Class, error) {
 	response := classResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
+	err := c.getJSON(ctx, ResourceClass, key, c.getBaseURL()+"classes/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	startingEquipmentOption, err := c.replaceEquipmentCategoryOptionSetTypesToOptionsArrays(response.StartingEquipmentOptions)
+	startingEquipmentOption, err := c.replaceEquipmentCategoryOptionSetTypesToOptionsArrays(ctx, response.StartingEquipmentOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	armorProfs, weaponProfs, toolProfs := categorizeProficiencies(response.Proficiencies)
-	
-	class := &entities.Class{
-		Key:                      response.Index,
-		Name:                     response.Name,
-		HitDie:                   response.HitDie,
-		Proficiencies:            referenceItemsToReferenceItems(response.Proficiencies),
-		SavingThrows:             referenceItemsToReferenceItems(response.SavingThrows),
-		StartingEquipment:        startingEquipmentResultsToStartingEquipment(response.StartingEquipment),
-		ProficiencyChoices:       choiceResultsToChoices(response.ProficiencyChoices),
-		StartingEquipmentOptions: startingEquipmentOption,
-		PrimaryAbilities:         extractPrimaryAbilities(response.MultiClassing),
-		Description:              getClassDescription(response.Index),
-		ArmorProficiencies:       armorProfs,
-		WeaponProficiencies:      weaponProfs,
-		ToolProficiencies:        toolProfs,
-	}
-
-	return class, nil
+	return classResultToClass(&response, startingEquipmentOption), nil
 }
 
-func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypesToOptionsArrays(input []*choiceResult) ([]*entities.ChoiceOption, error) {
+func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypesToOptionsArrays(ctx context.Context, input []*choiceResult) ([]*entities.ChoiceOption, error) {
 	out := make([]*entities.ChoiceOption, len(input))
 	for i, item := range input { // item is a choice
-		newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(item)
+		newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(ctx, item)
 		if err != nil {
 			return nil, err
 		}
@@ -304,7 +369,7 @@ func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypesToOptionsArrays(input [
 	return out, nil
 }
 
-func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypeToOptionsArray(input *choiceResult) (*choiceResult, error) {
+func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypeToOptionsArray(ctx context.Context, input *choiceResult) (*choiceResult, error) {
 	if input == nil {
 		return nil, errors.New("input is nil")
 	}
@@ -320,7 +385,7 @@ func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypeToOptionsArray(input *ch
 	if input.From.OptionSetType == "options_array" {
 		for idx, option := range input.From.Options {
 			if option.OptionType == "choice" {
-				newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(option.Choice)
+				newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(ctx, option.Choice)
 				if err != nil {
 					return nil, err
 				}
@@ -329,7 +394,7 @@ func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypeToOptionsArray(input *ch
 			} else if option.OptionType == "multiple" {
 				for idx2, multiple := range option.Items {
 					if multiple.OptionType == "choice" {
-						newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(multiple.Choice)
+						newChoice, err := c.replaceEquipmentCategoryOptionSetTypeToOptionsArray(ctx, multiple.Choice)
 						if err != nil {
 							return nil, err
 						}
@@ -354,7 +419,7 @@ func (c *dnd5eAPI) replaceEquipmentCategoryOptionSetTypeToOptionsArray(input *ch
 		return input, nil
 	}
 
-	equipment, err := c.listEquipmentByCategory(input.From.EquipmentCategory.Index)
+	equipment, err := c.listEquipmentByCategory(ctx, input.From.EquipmentCategory.Index)
 	if err != nil {
 		return nil, err
 	}
@@ -380,12 +445,16 @@ type ListSpellsInput struct {
 }
 
 func (c *dnd5eAPI) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	return c.ListSpellsContext(context.Background(), input)
+}
+
+func (c *dnd5eAPI) ListSpellsContext(ctx context.Context, input *ListSpellsInput) ([]*entities.ReferenceItem, error) {
 	if input == nil {
 		return nil, errors.New("input is nil")
 	}
 
 	if input.Class == "" {
-		levelList, err := c.doGetSpellsByLevel(input.Level)
+		levelList, err := c.doGetSpellsByLevel(ctx, input.Level)
 		if err != nil {
 			return nil, err
 		}
@@ -399,7 +468,7 @@ func (c *dnd5eAPI) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem
 	}
 
 	if input.Level == nil {
-		classList, err := c.doGetSpellsByClass(input.Class)
+		classList, err := c.doGetSpellsByClass(ctx, input.Class)
 		if err != nil {
 			return nil, err
 		}
@@ -412,7 +481,7 @@ func (c *dnd5eAPI) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem
 		return classOut, nil
 	}
 
-	levelList, err := c.doGetSpellsByLevel(input.Level)
+	levelList, err := c.doGetSpellsByLevel(ctx, input.Level)
 	if err != nil {
 		return nil, err
 	}
@@ -422,7 +491,7 @@ func (c *dnd5eAPI) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem
 		levelMap[r.Index] = true
 	}
 
-	classList, err := c.doGetSpellsByClass(input.Class)
+	classList, err := c.doGetSpellsByClass(ctx, input.Class)
 	if err != nil {
 		return nil, err
 	}
@@ -437,7 +506,7 @@ func (c *dnd5eAPI) ListSpells(input *ListSpellsInput) ([]*entities.ReferenceItem
 	return out, nil
 }
 
-func (c *dnd5eAPI) doGetSpellsByLevel(level *int) ([]*referenceItem, error) {
+func (c *dnd5eAPI) doGetSpellsByLevel(ctx context.Context, level *int) ([]*referenceItem, error) {
 	var url string
 	if level == nil {
 		url = c.getBaseURL() + "spells"
@@ -445,18 +514,9 @@ func (c *dnd5eAPI) doGetSpellsByLevel(level *int) ([]*referenceItem, error) {
 		url = c.getBaseURL() + "spells?level=" + strconv.Itoa(*level)
 	}
 
-	resp, err := c.client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
 	response := listResponse{}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := c.getJSON(ctx, ResourceSpell, "", url, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -464,25 +524,16 @@ func (c *dnd5eAPI) doGetSpellsByLevel(level *int) ([]*referenceItem, error) {
 	return response.Results, nil
 }
 
-func (c *dnd5eAPI) doGetSpellsByClass(class string) ([]*referenceItem, error) {
+func (c *dnd5eAPI) doGetSpellsByClass(ctx context.Context, class string) ([]*referenceItem, error) {
 	if class == "" {
 		return nil, errors.New("class is empty")
 	}
 
 	url := c.getBaseURL() + "classes/" + class + "/spells"
 
-	resp, err := c.client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
 	response := listResponse{}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := c.getJSON(ctx, ResourceSpell, "", url, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -491,23 +542,13 @@ func (c *dnd5eAPI) doGetSpellsByClass(class string) ([]*referenceItem, error) {
 }
 
 func (c *dnd5eAPI) GetSpell(key string) (*entities.Spell, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "spells/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetSpellContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetSpellContext(ctx context.Context, key string) (*entities.Spell, error) {
 	response := spellResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
+	err := c.getJSON(ctx, ResourceSpell, key, c.getBaseURL()+"spells/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -532,123 +573,44 @@ func (c *dnd5eAPI) GetSpell(key string) (*entities.Spell, error) {
 }
 
 func (c *dnd5eAPI) ListFeatures() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "features")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
+	return c.ListFeaturesContext(context.Background())
+}
 
-	return out, nil
+func (c *dnd5eAPI) ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceFeature, c.getBaseURL()+"features")
 }
 
 func (c *dnd5eAPI) GetFeature(key string) (*entities.Feature, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "features/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetFeatureContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error) {
 	response := featureResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-
+	err := c.getJSON(ctx, ResourceFeature, key, c.getBaseURL()+"features/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	feature := &entities.Feature{
-		Key:   response.Index,
-		Name:  response.Name,
-		Level: response.Level, //TODO: add prerequisites?
-		Class: referenceItemToReferenceItem(response.Class),
-	}
-
-	if response.FeatureSpecific != nil {
-		feature.FeatureSpecific = &entities.SubFeatureOption{
-			SubFeatureOptions: choiceResultToChoice(response.FeatureSpecific.SubFeatureOptions),
-		}
-	}
-
-	return feature, nil
+	return featureResultToFeature(&response), nil
 }
 
 func (c *dnd5eAPI) ListSkills() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "skills")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
+	return c.ListSkillsContext(context.Background())
+}
 
-	return out, nil
+func (c *dnd5eAPI) ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceSkill, c.getBaseURL()+"skills")
 }
 
 func (c *dnd5eAPI) GetSkill(key string) (*entities.Skill, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "skills/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetSkillContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetSkillContext(ctx context.Context, key string) (*entities.Skill, error) {
 	response := skillResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-
+	err := c.getJSON(ctx, ResourceSkill, key, c.getBaseURL()+"skills/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -656,7 +618,7 @@ func (c *dnd5eAPI) GetSkill(key string) (*entities.Skill, error) {
 	skill := &entities.Skill{
 		Key:          response.Index,
 		Name:         response.Name,
-		Description: response.Description,
+		Description:  response.Description,
 		AbilityScore: referenceItemToReferenceItem(response.AbilityScore),
 		Type:         urlToType(response.URL),
 	}
@@ -669,99 +631,41 @@ type ListMonstersInput struct {
 }
 
 func (c *dnd5eAPI) ListMonsters() ([]*entities.ReferenceItem, error) {
-	return c.ListMonstersWithFilter(nil)
+	return c.ListMonstersContext(context.Background())
+}
+
+func (c *dnd5eAPI) ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.ListMonstersWithFilterContext(ctx, nil)
 }
 
 func (c *dnd5eAPI) ListMonstersWithFilter(input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
+	return c.ListMonstersWithFilterContext(context.Background(), input)
+}
+
+func (c *dnd5eAPI) ListMonstersWithFilterContext(ctx context.Context, input *ListMonstersInput) ([]*entities.ReferenceItem, error) {
 	url := c.getBaseURL() + "monsters"
-	
+
 	// Add query parameters if provided
 	if input != nil && input.ChallengeRating != nil {
 		url = fmt.Sprintf("%s?challenge_rating=%g", url, *input.ChallengeRating)
 	}
-	
-	resp, err := c.client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
-
-	return out, nil
+	return c.getList(ctx, ResourceMonster, url)
 }
 
 func (c *dnd5eAPI) GetMonster(key string) (*entities.Monster, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "monsters/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetMonsterContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
 	response := monsterResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-
+	err := c.getJSON(ctx, ResourceMonster, key, c.getBaseURL()+"monsters/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	monster := &entities.Monster{
-		Key:                   response.Index,
-		Name:                  response.Name,
-		Size:                  response.Size,
-		Type:                  response.Type,
-		Alignment:             response.Alignment,
-		ArmorClass:            monsterArmorClassToValue(response.ArmorClass),
-		HitPoints:             response.HitPoints,
-		HitDice:               response.HitDice,
-		Speed:                 monsterSpeedResultToSpeed(response.Speed),
-		Strength:              response.Strength,
-		Dexterity:             response.Dexterity,
-		Constitution:          response.Constitution,
-		Intelligence:          response.Intelligence,
-		Wisdom:                response.Wisdom,
-		Charisma:              response.Charisma,
-		Proficiencies:         monsterProficiencyResultsToMonsterProficiencies(response.Proficiencies),
-		DamageVulnerabilities: response.DamageVulnerabilities,
-		DamageResistances:     response.DamageResistances,
-		DamageImmunities:      response.DamageImmunities,
-		ConditionImmunities:   referenceItemsToReferenceItems(response.ConditionImmunities),
-		MonsterSenses:         monsterSensesResultToMonsterSenses(response.Senses),
-		Languages:             response.Languages,
-		ChallengeRating:       response.ChallengeRating,
-		XP:                    response.XP,
-		MonsterActions:        monsterActionResultsToMonsterActions(response.MonsterActions),
-		MonsterImageURL:       response.MonsterImageURL,
-	}
-
-	return monster, nil
+	return monsterResultToMonster(&response), nil
 }
 
 func monsterArmorClassToValue(input []*monsterArmorClass) int {
@@ -780,7 +684,12 @@ func monsterArmorClassToValue(input []*monsterArmorClass) int {
 
 	return sum
 }
+
 func (c *dnd5eAPI) GetClassLevel(key string, level int) (*entities.Level, error) {
+	return c.GetClassLevelContext(context.Background(), key, level)
+}
+
+func (c *dnd5eAPI) GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error) {
 	if key == "" {
 		return nil, errors.New("key is required")
 	}
@@ -789,68 +698,25 @@ func (c *dnd5eAPI) GetClassLevel(key string, level int) (*entities.Level, error)
 		return nil, errors.New("level is required")
 	}
 
-	resp, err := c.client.Get(c.getBaseURL() + "classes/" + key + "/levels/" + strconv.Itoa(level))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
 	response := &levelResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
+	levelKey := key + "/" + strconv.Itoa(level)
+	err := c.getJSON(ctx, ResourceClassLevel, levelKey, c.getBaseURL()+"classes/"+key+"/levels/"+strconv.Itoa(level), response)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(responseBody, response)
-
-	if err != nil {
-		return nil, err
-	}
-
-	classLevel := &entities.Level{
-		Level:               response.Level,
-		AbilityScoreBonuses: response.AbilityScoreBonuses,
-		ProfBonus:           response.ProfBonus,
-		Features:            referenceItemsToReferenceItems(response.Features),
-		SpellCasting:        spellCastingResultToSpellCasting(response.SpellCasting),
-		ClassSpecific:       levelResultToClassSpecific(response),
-		Key:                 response.Index,
-		Class:               referenceItemToReferenceItem(response.Class),
-	}
-
-	return classLevel, nil
+	return levelResultToLevel(response), nil
 }
 
 func (c *dnd5eAPI) GetProficiency(key string) (*entities.Proficiency, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "proficiencies/" + key)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Println(err)
-		}
-	}(resp.Body)
+	return c.GetProficiencyContext(context.Background(), key)
+}
 
+func (c *dnd5eAPI) GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error) {
 	response := proficiencyResult{}
 
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-
+	err := c.getJSON(ctx, ResourceProficiency, key, c.getBaseURL()+"proficiencies/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -866,54 +732,21 @@ func (c *dnd5eAPI) GetProficiency(key string) (*entities.Proficiency, error) {
 }
 
 func (c *dnd5eAPI) ListDamageTypes() ([]*entities.ReferenceItem, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "damage-types")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-	response := listResponse{}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		out[i] = referenceItemToReferenceItem(r)
-	}
+	return c.ListDamageTypesContext(context.Background())
+}
 
-	return out, nil
+func (c *dnd5eAPI) ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return c.getList(ctx, ResourceDamageType, c.getBaseURL()+"damage-types")
 }
 
 func (c *dnd5eAPI) GetDamageType(key string) (*entities.DamageType, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "damage-types/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.GetDamageTypeContext(context.Background(), key)
+}
 
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
+func (c *dnd5eAPI) GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error) {
 	response := damageTypeResult{}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBody, &response)
-
+	err := c.getJSON(ctx, ResourceDamageType, key, c.getBaseURL()+"damage-types/"+key, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -929,23 +762,13 @@ func (c *dnd5eAPI) GetDamageType(key string) (*entities.DamageType, error) {
 }
 
 func (c *dnd5eAPI) GetEquipmentCategory(key string) (*entities.EquipmentCategory, error) {
-	resp, err := c.client.Get(c.getBaseURL() + "equipment-categories/" + key)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != httpStatusOK {
-		return nil, newHTTPStatusError(resp.StatusCode)
-	}
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetEquipmentCategoryContext(context.Background(), key)
+}
 
+func (c *dnd5eAPI) GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error) {
 	category := &entities.EquipmentCategory{}
-	err = json.Unmarshal(responseBody, category)
+
+	err := c.getJSON(ctx, ResourceEquipmentCategory, key, c.getBaseURL()+"equipment-categories/"+key, category)
 	if err != nil {
 		return nil, err
 	}
@@ -954,43 +777,40 @@ func (c *dnd5eAPI) GetEquipmentCategory(key string) (*entities.EquipmentCategory
 }
 
 func (c *dnd5eAPI) ListBackgrounds() ([]*entities.ReferenceItem, error) {
+	return c.ListBackgroundsContext(context.Background())
+}
+
+func (c *dnd5eAPI) ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
 	// Try to get from API first
-	resp, err := c.client.Get(c.getBaseURL() + "backgrounds")
+	apiBackgrounds, err := c.getList(ctx, ResourceBackground, c.getBaseURL()+"backgrounds")
 	if err != nil {
-		// If API fails, return hardcoded backgrounds
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// The hardcoded backgrounds follow the 2014 rules
+		if c.rules == Rules2024 {
+			return nil, err
+		}
+		// If the API fails, returns an error status or can't be parsed, return hardcoded backgrounds
 		return getHardcodedBackgrounds(), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != httpStatusOK {
-		// If API returns error, return hardcoded backgrounds
-		return getHardcodedBackgrounds(), nil
-	}
-	
-	response := listResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		// If parsing fails, return hardcoded backgrounds
-		return getHardcodedBackgrounds(), nil
+	// GetBackground has no hardcoded fallback for 2024, so don't list any
+	if c.rules == Rules2024 {
+		return apiBackgrounds, nil
 	}
 
-	// Merge API results with hardcoded backgrounds
-	apiBackgrounds := make([]*entities.ReferenceItem, len(response.Results))
-	for i, r := range response.Results {
-		apiBackgrounds[i] = referenceItemToReferenceItem(r)
-	}
-	
 	// Get hardcoded backgrounds and merge, avoiding duplicates
 	hardcodedBackgrounds := getHardcodedBackgrounds()
 	merged := make([]*entities.ReferenceItem, 0, len(apiBackgrounds)+len(hardcodedBackgrounds))
 	merged = append(merged, apiBackgrounds...)
-	
+
 	// Add hardcoded backgrounds that aren't in API results
 	apiKeys := make(map[string]bool)
 	for _, bg := range apiBackgrounds {
 		apiKeys[bg.Key] = true
 	}
-	
+
 	for _, bg := range hardcodedBackgrounds {
 		if !apiKeys[bg.Key] {
 			merged = append(merged, bg)
@@ -1001,38 +821,58 @@ func (c *dnd5eAPI) ListBackgrounds() ([]*entities.ReferenceItem, error) {
 }
 
 func (c *dnd5eAPI) GetBackground(key string) (*entities.Background, error) {
+	return c.GetBackgroundContext(context.Background(), key)
+}
+
+func (c *dnd5eAPI) GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error) {
 	// Try to get from API first
-	resp, err := c.client.Get(c.getBaseURL() + "backgrounds/" + key)
+	response := backgroundResult{}
+
+	err := c.getJSON(ctx, ResourceBackground, key, c.getBaseURL()+"backgrounds/"+key, &response)
 	if err != nil {
-		// If API fails, try hardcoded background
-		return getHardcodedBackground(key)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// The hardcoded backgrounds follow the 2014 rules
+		if c.rules == Rules2024 {
+			return nil, err
+		}
+
+		// If the API fails, returns 404 or can't be parsed, try hardcoded background
+		background, hardcodedErr := getHardcodedBackground(key)
+		if hardcodedErr != nil {
+			// Report the API failure, which carries the status and URL
+			return nil, err
+		}
+
+		return background, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != httpStatusOK {
-		// If API returns 404 or other error, try hardcoded background
-		return getHardcodedBackground(key)
+	proficiencies := response.StartingProficiencies
+	if proficiencies == nil {
+		proficiencies = response.Proficiencies
 	}
-	
-	response := backgroundResult{}
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		// If parsing fails, try hardcoded background
-		return getHardcodedBackground(key)
+
+	equipmentOptions := response.StartingEquipmentOptions
+	if equipmentOptions == nil {
+		equipmentOptions = response.EquipmentOptions
 	}
 
 	background := &entities.Background{
-		Key:                        response.Index,
-		Name:                       response.Name,
-		SkillProficiencies:         referenceItemsToReferenceItems(response.StartingProficiencies),
-		LanguageOptions:            choiceResultToChoice(response.LanguageOptions),
-		StartingEquipment:          startingEquipmentResultsToStartingEquipment(response.StartingEquipment),
-		StartingEquipmentOptions:   choiceResultsToChoices(response.StartingEquipmentOptions),
-		Feature:                    backgroundFeatureResultToBackgroundFeature(response.Feature),
-		PersonalityTraits:          choiceResultToChoice(response.PersonalityTraits),
-		Ideals:                     choiceResultToChoice(response.Ideals),
-		Bonds:                      choiceResultToChoice(response.Bonds),
-		Flaws:                      choiceResultToChoice(response.Flaws),
+		Key:                      response.Index,
+		Name:                     response.Name,
+		SkillProficiencies:       referenceItemsToReferenceItems(proficiencies),
+		LanguageOptions:          choiceResultToChoice(response.LanguageOptions),
+		StartingEquipment:        startingEquipmentResultsToStartingEquipment(response.StartingEquipment),
+		StartingEquipmentOptions: choiceResultsToChoices(equipmentOptions),
+		AbilityScores:            referenceItemsToReferenceItems(response.AbilityScores),
+		Feat:                     referenceItemToReferenceItem(response.Feat),
+		ProficiencyChoices:       choiceResultsToChoices(response.ProficiencyChoices),
+		Feature:                  backgroundFeatureResultToBackgroundFeature(response.Feature),
+		PersonalityTraits:        choiceResultToChoice(response.PersonalityTraits),
+		Ideals:                   choiceResultToChoice(response.Ideals),
+		Bonds:                    choiceResultToChoice(response.Bonds),
+		Flaws:                    choiceResultToChoice(response.Flaws),
 	}
 
 	return background, nil
@@ -2,15 +2,18 @@ package dnd5e
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/fadedpez/dnd5e-api/entities"
 
@@ -1796,3 +1799,153 @@ func TestDnd5eAPI_GetDamageType(t *testing.T) {
 	assert.Equal(t, "Acid", damageType.Name)
 	assert.Equal(t, "The corrosive spray of a black dragon's breath and the dissolving enzymes secreted by a black pudding deal acid damage.", damageType.Description[0])
 }
+
+func TestDND5eAPI_Context(t *testing.T) {
+	t.Run("returns error without calling Get if ctx is already cancelled", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := dnd5eAPI.GetMonsterContext(ctx, "goblin")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		client.AssertNotCalled(t, "Get", baserulzURL+"monsters/goblin")
+	})
+
+	t.Run("propagates ctx deadline to the http request", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		dnd5eAPI := &dnd5eAPI{client: server.Client(), baseURL: server.URL + "/api/"}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := dnd5eAPI.ListRacesContext(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("does not fall back to hardcoded backgrounds when ctx is cancelled", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actual, err := dnd5eAPI.ListBackgroundsContext(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestDND5eAPI_Errors(t *testing.T) {
+	statusResponse := func(code int) *http.Response {
+		return &http.Response{
+			StatusCode: code,
+			Body:       io.NopCloser(bytes.NewReader([]byte(``))),
+		}
+	}
+
+	t.Run("returns a not found StatusError for a 404", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"monsters/not-a-goblin").Return(statusResponse(404), nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		_, err := dnd5eAPI.GetMonster("not-a-goblin")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrServer)
+
+		var statusErr *StatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, 404, statusErr.StatusCode)
+		assert.Equal(t, baserulzURL+"monsters/not-a-goblin", statusErr.URL)
+		assert.Equal(t, ResourceMonster, statusErr.Kind)
+		assert.Equal(t, "not-a-goblin", statusErr.Key)
+	})
+
+	t.Run("returns a rate limited StatusError for a 429", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"spells").Return(statusResponse(429), nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		_, err := dnd5eAPI.ListSpells(&ListSpellsInput{})
+
+		assert.ErrorIs(t, err, ErrRateLimited)
+
+		var statusErr *StatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, ResourceSpell, statusErr.Kind)
+		assert.Equal(t, "", statusErr.Key)
+	})
+
+	t.Run("returns a server StatusError for a 5xx", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"classes/wizard/levels/3").Return(statusResponse(503), nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		_, err := dnd5eAPI.GetClassLevel("wizard", 3)
+
+		assert.ErrorIs(t, err, ErrServer)
+		assert.NotErrorIs(t, err, ErrNotFound)
+
+		var statusErr *StatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, ResourceClassLevel, statusErr.Kind)
+		assert.Equal(t, "wizard/3", statusErr.Key)
+	})
+
+	t.Run("returns a DecodeError for an invalid body", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"equipment/abacus").Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`invalid`))),
+		}, nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		_, err := dnd5eAPI.GetEquipment("abacus")
+
+		assert.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Equal(t, ResourceEquipment, decodeErr.Kind)
+		assert.Equal(t, "abacus", decodeErr.Key)
+		assert.Equal(t, baserulzURL+"equipment/abacus", decodeErr.URL)
+		assert.NotNil(t, decodeErr.Unwrap())
+	})
+
+	t.Run("returns the API error for a background that is not hardcoded", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"backgrounds/pirate").Return(statusResponse(404), nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		_, err := dnd5eAPI.GetBackground("pirate")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("falls back to a hardcoded background on a 404", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"backgrounds/sage").Return(statusResponse(404), nil)
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL}
+		actual, err := dnd5eAPI.GetBackground("sage")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sage", actual.Key)
+	})
+}
@@ -0,0 +1,531 @@
+package dnd5etest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+// listTypes holds the ReferenceItem.Type the real API reports for each kind,
+// which is the resource's path segment.
+var listTypes = map[dnd5e.ResourceKind]string{
+	dnd5e.ResourceRace:              "races",
+	dnd5e.ResourceEquipment:         "equipment",
+	dnd5e.ResourceClass:             "classes",
+	dnd5e.ResourceSpell:             "spells",
+	dnd5e.ResourceFeature:           "features",
+	dnd5e.ResourceSkill:             "skills",
+	dnd5e.ResourceMonster:           "monsters",
+	dnd5e.ResourceClassLevel:        "classes",
+	dnd5e.ResourceProficiency:       "proficiencies",
+	dnd5e.ResourceDamageType:        "damage-types",
+	dnd5e.ResourceEquipmentCategory: "equipment-categories",
+	dnd5e.ResourceBackground:        "backgrounds",
+}
+
+// Call is a method invocation recorded by a Fake
+type Call struct {
+	// Method is the Interface method name without the Context suffix,
+	// e.g. "GetMonster".
+	Method string
+	// Key is the requested key, "wizard/3" for GetClassLevel and empty for
+	// list methods.
+	Key string
+}
+
+type fakeItem struct {
+	name  string
+	value interface{}
+}
+
+type errorKey struct {
+	method string
+	key    string
+}
+
+// Fake is an in-memory implementation of dnd5e.Interface for unit tests that
+// don't need HTTP at all. Seed it with the Add methods and program failures
+// with SetError and SetLatency. Lookups of unseeded keys fail with a 404
+// *dnd5e.StatusError, so errors.Is(err, dnd5e.ErrNotFound) holds just like
+// against the real API.
+//
+// Get methods return the seeded pointers themselves, not copies. A Fake is
+// safe for concurrent use.
+type Fake struct {
+	mu      sync.RWMutex
+	items   map[dnd5e.ResourceKind]map[string]fakeItem
+	errs    map[errorKey]error
+	latency time.Duration
+	calls   []Call
+}
+
+// NewFake returns an empty Fake
+func NewFake() *Fake {
+	return &Fake{
+		items: make(map[dnd5e.ResourceKind]map[string]fakeItem),
+		errs:  make(map[errorKey]error),
+	}
+}
+
+func (f *Fake) add(kind dnd5e.ResourceKind, key, name string, value interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.items[kind] == nil {
+		f.items[kind] = make(map[string]fakeItem)
+	}
+
+	f.items[kind][key] = fakeItem{name: name, value: value}
+}
+
+// AddRaces seeds races, keyed by Key
+func (f *Fake) AddRaces(races ...*entities.Race) {
+	for _, r := range races {
+		f.add(dnd5e.ResourceRace, r.Key, r.Name, r)
+	}
+}
+
+// AddEquipment seeds equipment, keyed by Key. Items must be *entities.Equipment,
+// *entities.Weapon or *entities.Armor; any other type panics.
+func (f *Fake) AddEquipment(items ...dnd5e.EquipmentInterface) {
+	for _, item := range items {
+		switch e := item.(type) {
+		case *entities.Equipment:
+			f.add(dnd5e.ResourceEquipment, e.Key, e.Name, e)
+		case *entities.Weapon:
+			f.add(dnd5e.ResourceEquipment, e.Key, e.Name, e)
+		case *entities.Armor:
+			f.add(dnd5e.ResourceEquipment, e.Key, e.Name, e)
+		default:
+			panic(fmt.Sprintf("dnd5etest: unsupported equipment type %T", item))
+		}
+	}
+}
+
+// AddClasses seeds classes, keyed by Key
+func (f *Fake) AddClasses(classes ...*entities.Class) {
+	for _, c := range classes {
+		f.add(dnd5e.ResourceClass, c.Key, c.Name, c)
+	}
+}
+
+// AddSpells seeds spells, keyed by Key. ListSpells filters on SpellLevel and
+// SpellClasses.
+func (f *Fake) AddSpells(spells ...*entities.Spell) {
+	for _, s := range spells {
+		f.add(dnd5e.ResourceSpell, s.Key, s.Name, s)
+	}
+}
+
+// AddFeatures seeds features, keyed by Key
+func (f *Fake) AddFeatures(features ...*entities.Feature) {
+	for _, feat := range features {
+		f.add(dnd5e.ResourceFeature, feat.Key, feat.Name, feat)
+	}
+}
+
+// AddSkills seeds skills, keyed by Key
+func (f *Fake) AddSkills(skills ...*entities.Skill) {
+	for _, s := range skills {
+		f.add(dnd5e.ResourceSkill, s.Key, s.Name, s)
+	}
+}
+
+// AddMonsters seeds monsters, keyed by Key. ListMonstersWithFilter filters on
+// ChallengeRating.
+func (f *Fake) AddMonsters(monsters ...*entities.Monster) {
+	for _, m := range monsters {
+		f.add(dnd5e.ResourceMonster, m.Key, m.Name, m)
+	}
+}
+
+// AddClassLevels seeds class levels, keyed by Class.Key and Level
+func (f *Fake) AddClassLevels(levels ...*entities.Level) {
+	for _, l := range levels {
+		class := ""
+		if l.Class != nil {
+			class = l.Class.Key
+		}
+
+		f.add(dnd5e.ResourceClassLevel, class+"/"+strconv.Itoa(l.Level), l.Key, l)
+	}
+}
+
+// AddProficiencies seeds proficiencies, keyed by Key
+func (f *Fake) AddProficiencies(proficiencies ...*entities.Proficiency) {
+	for _, p := range proficiencies {
+		f.add(dnd5e.ResourceProficiency, p.Key, p.Name, p)
+	}
+}
+
+// AddDamageTypes seeds damage types, keyed by Key
+func (f *Fake) AddDamageTypes(damageTypes ...*entities.DamageType) {
+	for _, d := range damageTypes {
+		f.add(dnd5e.ResourceDamageType, d.Key, d.Name, d)
+	}
+}
+
+// AddEquipmentCategories seeds equipment categories, keyed by Index
+func (f *Fake) AddEquipmentCategories(categories ...*entities.EquipmentCategory) {
+	for _, c := range categories {
+		f.add(dnd5e.ResourceEquipmentCategory, c.Index, c.Name, c)
+	}
+}
+
+// AddBackgrounds seeds backgrounds, keyed by Key
+func (f *Fake) AddBackgrounds(backgrounds ...*entities.Background) {
+	for _, b := range backgrounds {
+		f.add(dnd5e.ResourceBackground, b.Key, b.Name, b)
+	}
+}
+
+// SetError makes method fail with err for key. method is the Interface method
+// name without the Context suffix, e.g. "GetMonster"; key is matched as in
+// Call and an empty key matches every call to the method. A nil err clears it.
+func (f *Fake) SetError(method, key string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := errorKey{method: method, key: key}
+	if err == nil {
+		delete(f.errs, k)
+		return
+	}
+
+	f.errs[k] = err
+}
+
+// SetLatency delays every call by d, or until its context is done
+func (f *Fake) SetLatency(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.latency = d
+}
+
+// Calls returns the calls made so far, in order
+func (f *Fake) Calls() []Call {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// begin records the call, waits out the latency and returns the programmed
+// error, if any.
+func (f *Fake) begin(ctx context.Context, method, key string) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Method: method, Key: key})
+	latency := f.latency
+	err, ok := f.errs[errorKey{method: method, key: key}]
+	if !ok {
+		err = f.errs[errorKey{method: method}]
+	}
+	f.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return err
+}
+
+func getFake[T any](ctx context.Context, f *Fake, method string, kind dnd5e.ResourceKind, key string) (T, error) {
+	var zero T
+
+	if err := f.begin(ctx, method, key); err != nil {
+		return zero, err
+	}
+
+	if key == "" {
+		return zero, errors.New("key is required")
+	}
+
+	f.mu.RLock()
+	item, ok := f.items[kind][key]
+	f.mu.RUnlock()
+
+	if !ok {
+		return zero, &dnd5e.StatusError{StatusCode: http.StatusNotFound, Kind: kind, Key: key}
+	}
+
+	return item.value.(T), nil
+}
+
+// list returns the seeded items of kind accepted by keep, sorted by key
+func (f *Fake) list(ctx context.Context, method string, kind dnd5e.ResourceKind, keep func(interface{}) bool) ([]*entities.ReferenceItem, error) {
+	if err := f.begin(ctx, method, ""); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	keys := make([]string, 0, len(f.items[kind]))
+	for key, item := range f.items[kind] {
+		if keep == nil || keep(item.value) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	out := make([]*entities.ReferenceItem, len(keys))
+	for i, key := range keys {
+		out[i] = &entities.ReferenceItem{
+			Key:  key,
+			Name: f.items[kind][key].name,
+			Type: listTypes[kind],
+		}
+	}
+
+	return out, nil
+}
+
+func (f *Fake) ListRaces() ([]*entities.ReferenceItem, error) {
+	return f.ListRacesContext(context.Background())
+}
+
+func (f *Fake) ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListRaces", dnd5e.ResourceRace, nil)
+}
+
+func (f *Fake) GetRace(key string) (*entities.Race, error) {
+	return f.GetRaceContext(context.Background(), key)
+}
+
+func (f *Fake) GetRaceContext(ctx context.Context, key string) (*entities.Race, error) {
+	return getFake[*entities.Race](ctx, f, "GetRace", dnd5e.ResourceRace, key)
+}
+
+func (f *Fake) ListEquipment() ([]*entities.ReferenceItem, error) {
+	return f.ListEquipmentContext(context.Background())
+}
+
+func (f *Fake) ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListEquipment", dnd5e.ResourceEquipment, nil)
+}
+
+func (f *Fake) GetEquipment(key string) (dnd5e.EquipmentInterface, error) {
+	return f.GetEquipmentContext(context.Background(), key)
+}
+
+func (f *Fake) GetEquipmentContext(ctx context.Context, key string) (dnd5e.EquipmentInterface, error) {
+	return getFake[dnd5e.EquipmentInterface](ctx, f, "GetEquipment", dnd5e.ResourceEquipment, key)
+}
+
+func (f *Fake) ListClasses() ([]*entities.ReferenceItem, error) {
+	return f.ListClassesContext(context.Background())
+}
+
+func (f *Fake) ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListClasses", dnd5e.ResourceClass, nil)
+}
+
+func (f *Fake) GetClass(key string) (*entities.Class, error) {
+	return f.GetClassContext(context.Background(), key)
+}
+
+func (f *Fake) GetClassContext(ctx context.Context, key string) (*entities.Class, error) {
+	return getFake[*entities.Class](ctx, f, "GetClass", dnd5e.ResourceClass, key)
+}
+
+func (f *Fake) ListSpells(input *dnd5e.ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	return f.ListSpellsContext(context.Background(), input)
+}
+
+func (f *Fake) ListSpellsContext(ctx context.Context, input *dnd5e.ListSpellsInput) ([]*entities.ReferenceItem, error) {
+	if input == nil {
+		return nil, errors.New("input is nil")
+	}
+
+	return f.list(ctx, "ListSpells", dnd5e.ResourceSpell, func(v interface{}) bool {
+		spell := v.(*entities.Spell)
+		if input.Level != nil && spell.SpellLevel != *input.Level {
+			return false
+		}
+
+		if input.Class == "" {
+			return true
+		}
+
+		for _, class := range spell.SpellClasses {
+			if class != nil && class.Key == input.Class {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (f *Fake) GetSpell(key string) (*entities.Spell, error) {
+	return f.GetSpellContext(context.Background(), key)
+}
+
+func (f *Fake) GetSpellContext(ctx context.Context, key string) (*entities.Spell, error) {
+	return getFake[*entities.Spell](ctx, f, "GetSpell", dnd5e.ResourceSpell, key)
+}
+
+func (f *Fake) ListFeatures() ([]*entities.ReferenceItem, error) {
+	return f.ListFeaturesContext(context.Background())
+}
+
+func (f *Fake) ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListFeatures", dnd5e.ResourceFeature, nil)
+}
+
+func (f *Fake) GetFeature(key string) (*entities.Feature, error) {
+	return f.GetFeatureContext(context.Background(), key)
+}
+
+func (f *Fake) GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error) {
+	return getFake[*entities.Feature](ctx, f, "GetFeature", dnd5e.ResourceFeature, key)
+}
+
+func (f *Fake) ListSkills() ([]*entities.ReferenceItem, error) {
+	return f.ListSkillsContext(context.Background())
+}
+
+func (f *Fake) ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListSkills", dnd5e.ResourceSkill, nil)
+}
+
+func (f *Fake) GetSkill(key string) (*entities.Skill, error) {
+	return f.GetSkillContext(context.Background(), key)
+}
+
+func (f *Fake) GetSkillContext(ctx context.Context, key string) (*entities.Skill, error) {
+	return getFake[*entities.Skill](ctx, f, "GetSkill", dnd5e.ResourceSkill, key)
+}
+
+func (f *Fake) ListMonsters() ([]*entities.ReferenceItem, error) {
+	return f.ListMonstersContext(context.Background())
+}
+
+func (f *Fake) ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListMonsters", dnd5e.ResourceMonster, nil)
+}
+
+func (f *Fake) ListMonstersWithFilter(input *dnd5e.ListMonstersInput) ([]*entities.ReferenceItem, error) {
+	return f.ListMonstersWithFilterContext(context.Background(), input)
+}
+
+func (f *Fake) ListMonstersWithFilterContext(ctx context.Context, input *dnd5e.ListMonstersInput) ([]*entities.ReferenceItem, error) {
+	var keep func(interface{}) bool
+	if input != nil && input.ChallengeRating != nil {
+		cr := float32(*input.ChallengeRating)
+		keep = func(v interface{}) bool {
+			return v.(*entities.Monster).ChallengeRating == cr
+		}
+	}
+
+	return f.list(ctx, "ListMonstersWithFilter", dnd5e.ResourceMonster, keep)
+}
+
+func (f *Fake) GetMonster(key string) (*entities.Monster, error) {
+	return f.GetMonsterContext(context.Background(), key)
+}
+
+func (f *Fake) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
+	return getFake[*entities.Monster](ctx, f, "GetMonster", dnd5e.ResourceMonster, key)
+}
+
+func (f *Fake) GetClassLevel(key string, level int) (*entities.Level, error) {
+	return f.GetClassLevelContext(context.Background(), key, level)
+}
+
+func (f *Fake) GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error) {
+	if key == "" {
+		return nil, errors.New("key is required")
+	}
+
+	if level == 0 {
+		return nil, errors.New("level is required")
+	}
+
+	return getFake[*entities.Level](ctx, f, "GetClassLevel", dnd5e.ResourceClassLevel, key+"/"+strconv.Itoa(level))
+}
+
+func (f *Fake) GetProficiency(key string) (*entities.Proficiency, error) {
+	return f.GetProficiencyContext(context.Background(), key)
+}
+
+func (f *Fake) GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error) {
+	return getFake[*entities.Proficiency](ctx, f, "GetProficiency", dnd5e.ResourceProficiency, key)
+}
+
+func (f *Fake) ListDamageTypes() ([]*entities.ReferenceItem, error) {
+	return f.ListDamageTypesContext(context.Background())
+}
+
+func (f *Fake) ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListDamageTypes", dnd5e.ResourceDamageType, nil)
+}
+
+func (f *Fake) GetDamageType(key string) (*entities.DamageType, error) {
+	return f.GetDamageTypeContext(context.Background(), key)
+}
+
+func (f *Fake) GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error) {
+	return getFake[*entities.DamageType](ctx, f, "GetDamageType", dnd5e.ResourceDamageType, key)
+}
+
+func (f *Fake) GetEquipmentCategory(key string) (*entities.EquipmentCategory, error) {
+	return f.GetEquipmentCategoryContext(context.Background(), key)
+}
+
+func (f *Fake) GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error) {
+	return getFake[*entities.EquipmentCategory](ctx, f, "GetEquipmentCategory", dnd5e.ResourceEquipmentCategory, key)
+}
+
+func (f *Fake) ListBackgrounds() ([]*entities.ReferenceItem, error) {
+	return f.ListBackgroundsContext(context.Background())
+}
+
+func (f *Fake) ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error) {
+	return f.list(ctx, "ListBackgrounds", dnd5e.ResourceBackground, nil)
+}
+
+func (f *Fake) GetBackground(key string) (*entities.Background, error) {
+	return f.GetBackgroundContext(context.Background(), key)
+}
+
+func (f *Fake) GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error) {
+	return getFake[*entities.Background](ctx, f, "GetBackground", dnd5e.ResourceBackground, key)
+}
+
+// GetMonsters looks up each key with GetMonsterContext, so calls, errors and
+// latency are recorded and applied per key
+func (f *Fake) GetMonsters(ctx context.Context, keys []string, opts *dnd5e.BatchOptions) ([]*entities.Monster, error) {
+	return dnd5e.Batch(ctx, keys, opts, f.GetMonsterContext)
+}
+
+// GetSpells looks up each key with GetSpellContext
+func (f *Fake) GetSpells(ctx context.Context, keys []string, opts *dnd5e.BatchOptions) ([]*entities.Spell, error) {
+	return dnd5e.Batch(ctx, keys, opts, f.GetSpellContext)
+}
+
+var (
+	_ dnd5e.Interface        = (*Fake)(nil)
+	_ dnd5e.ContextInterface = (*Fake)(nil)
+	_ dnd5e.BatchInterface   = (*Fake)(nil)
+)
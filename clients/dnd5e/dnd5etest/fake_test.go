@@ -0,0 +1,141 @@
+package dnd5etest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	fake := NewFake()
+	fake.AddMonsters(
+		&entities.Monster{Key: "goblin", Name: "Goblin", ChallengeRating: 0.25},
+		&entities.Monster{Key: "adult-red-dragon", Name: "Adult Red Dragon", ChallengeRating: 17},
+	)
+	fake.AddSpells(
+		&entities.Spell{Key: "fireball", Name: "Fireball", SpellLevel: 3, SpellClasses: []*entities.ReferenceItem{{Key: "wizard"}}},
+		&entities.Spell{Key: "cure-wounds", Name: "Cure Wounds", SpellLevel: 1, SpellClasses: []*entities.ReferenceItem{{Key: "cleric"}}},
+		&entities.Spell{Key: "magic-missile", Name: "Magic Missile", SpellLevel: 1, SpellClasses: []*entities.ReferenceItem{{Key: "wizard"}}},
+	)
+	fake.AddEquipment(&entities.Weapon{Key: "longsword", Name: "Longsword"})
+	fake.AddClassLevels(&entities.Level{Level: 3, Key: "wizard-3", Class: &entities.ReferenceItem{Key: "wizard"}})
+
+	t.Run("returns seeded entities", func(t *testing.T) {
+		monster, err := fake.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Goblin", monster.Name)
+
+		equipment, err := fake.GetEquipment("longsword")
+		assert.NoError(t, err)
+		assert.IsType(t, &entities.Weapon{}, equipment)
+
+		level, err := fake.GetClassLevel("wizard", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "wizard-3", level.Key)
+	})
+
+	t.Run("unseeded keys are not found", func(t *testing.T) {
+		_, err := fake.GetMonster("beholder")
+		assert.ErrorIs(t, err, dnd5e.ErrNotFound)
+
+		var statusErr *dnd5e.StatusError
+		assert.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, dnd5e.ResourceMonster, statusErr.Kind)
+		assert.Equal(t, "beholder", statusErr.Key)
+	})
+
+	t.Run("lists are sorted by key", func(t *testing.T) {
+		monsters, err := fake.ListMonsters()
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.ReferenceItem{
+			{Key: "adult-red-dragon", Name: "Adult Red Dragon", Type: "monsters"},
+			{Key: "goblin", Name: "Goblin", Type: "monsters"},
+		}, monsters)
+	})
+
+	t.Run("filters monsters by challenge rating", func(t *testing.T) {
+		cr := 0.25
+		monsters, err := fake.ListMonstersWithFilter(&dnd5e.ListMonstersInput{ChallengeRating: &cr})
+		assert.NoError(t, err)
+		assert.Len(t, monsters, 1)
+		assert.Equal(t, "goblin", monsters[0].Key)
+	})
+
+	t.Run("filters spells by level and class", func(t *testing.T) {
+		level := 1
+		spells, err := fake.ListSpells(&dnd5e.ListSpellsInput{Level: &level, Class: "wizard"})
+		assert.NoError(t, err)
+		assert.Len(t, spells, 1)
+		assert.Equal(t, "magic-missile", spells[0].Key)
+
+		spells, err = fake.ListSpells(&dnd5e.ListSpellsInput{Class: "wizard"})
+		assert.NoError(t, err)
+		assert.Len(t, spells, 2)
+
+		_, err = fake.ListSpells(nil)
+		assert.EqualError(t, err, "input is nil")
+	})
+}
+
+func TestFake_SetError(t *testing.T) {
+	fake := NewFake()
+	fake.AddMonsters(&entities.Monster{Key: "goblin"}, &entities.Monster{Key: "orc"})
+
+	boom := errors.New("boom")
+	fake.SetError("GetMonster", "goblin", boom)
+
+	_, err := fake.GetMonster("goblin")
+	assert.ErrorIs(t, err, boom)
+
+	_, err = fake.GetMonster("orc")
+	assert.NoError(t, err)
+
+	fake.SetError("GetMonster", "", dnd5e.ErrServer)
+	_, err = fake.GetMonsterContext(context.Background(), "orc")
+	assert.ErrorIs(t, err, dnd5e.ErrServer)
+
+	fake.SetError("GetMonster", "", nil)
+	fake.SetError("GetMonster", "goblin", nil)
+	_, err = fake.GetMonster("goblin")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Call{
+		{Method: "GetMonster", Key: "goblin"},
+		{Method: "GetMonster", Key: "orc"},
+		{Method: "GetMonster", Key: "orc"},
+		{Method: "GetMonster", Key: "goblin"},
+	}, fake.Calls())
+}
+
+func TestFake_SetLatency(t *testing.T) {
+	fake := NewFake()
+	fake.AddMonsters(&entities.Monster{Key: "goblin"})
+	fake.SetLatency(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := fake.GetMonsterContext(ctx, "goblin")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFake_CachedClient(t *testing.T) {
+	fake := NewFake()
+	fake.AddMonsters(&entities.Monster{Key: "goblin", Name: "Goblin"})
+
+	client, err := dnd5e.NewCachedClientWithConfig(&dnd5e.CachedClientConfig{Client: fake, TTL: time.Hour})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		monster, err := client.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Goblin", monster.Name)
+	}
+
+	assert.Len(t, fake.Calls(), 1)
+}
@@ -0,0 +1,75 @@
+// Package dnd5etest provides a stand-in for the D&D 5e API for integration
+// tests, in the spirit of net/http/httptest.
+package dnd5etest
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
+)
+
+// Server is an httptest.Server serving the D&D 5e API routes from a fixture
+// directory laid out like a snapshot: "monsters.json" for /api/monsters,
+// "monsters/goblin.json" for /api/monsters/goblin,
+// "classes/wizard/levels/3.json" for /api/classes/wizard/levels/3 and so on.
+// Filtered lists (?level=, ?challenge_rating=) are computed from the fixtures.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewServer starts a Server serving the fixtures in fsys. The caller should
+// call Close when finished, to shut it down.
+func NewServer(fsys fs.FS) *Server {
+	s := &Server{}
+	handler := dnd5e.NewSnapshotHandler(fsys)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.RequestURI())
+		s.mu.Unlock()
+
+		handler.ServeHTTP(w, r)
+	}))
+
+	return s
+}
+
+// NewServerFromDir starts a Server serving the fixtures in dir
+func NewServerFromDir(dir string) *Server {
+	return NewServer(os.DirFS(dir))
+}
+
+// BaseURL returns the URL to use as dnd5e.DND5eAPIConfig.BaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/"
+}
+
+// NewClient returns a client talking to the server
+func (s *Server) NewClient() dnd5e.Interface {
+	client, err := dnd5e.NewDND5eAPI(&dnd5e.DND5eAPIConfig{
+		Client:  s.Client(),
+		BaseURL: s.BaseURL(),
+	})
+	if err != nil {
+		// unreachable: the config is always complete
+		panic(err)
+	}
+
+	return client
+}
+
+// Requests returns the request URIs the server has received, in order, e.g.
+// "/api/spells?level=3"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
@@ -0,0 +1,96 @@
+package dnd5etest
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServerFromDir("../../../testdata/recorded")
+	defer server.Close()
+
+	client := server.NewClient()
+
+	t.Run("serves items", func(t *testing.T) {
+		monster, err := client.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Goblin", monster.Name)
+	})
+
+	t.Run("filters monsters by challenge rating", func(t *testing.T) {
+		cr := 0.25
+		monsters, err := client.ListMonstersWithFilter(&dnd5e.ListMonstersInput{ChallengeRating: &cr})
+		assert.NoError(t, err)
+		assert.Len(t, monsters, 1)
+		assert.Equal(t, "goblin", monsters[0].Key)
+	})
+
+	t.Run("serves class levels", func(t *testing.T) {
+		level, err := client.GetClassLevel("wizard", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, level.Level)
+	})
+
+	t.Run("missing fixtures are not found", func(t *testing.T) {
+		_, err := client.GetSpell("wish")
+		assert.ErrorIs(t, err, dnd5e.ErrNotFound)
+	})
+
+	t.Run("only serves GET", func(t *testing.T) {
+		resp, err := server.Client().Post(server.BaseURL()+"monsters/goblin", "application/json", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("records requests", func(t *testing.T) {
+		assert.Contains(t, server.Requests(), "/api/monsters?challenge_rating=0.25")
+	})
+}
+
+func TestServer_Spells(t *testing.T) {
+	list := `{"count": 2, "results": [
+		{"index": "fire-bolt", "name": "Fire Bolt", "url": "/api/spells/fire-bolt"},
+		{"index": "fireball", "name": "Fireball", "url": "/api/spells/fireball"}]}`
+
+	server := NewServer(fstest.MapFS{
+		"spells.json":                {Data: []byte(list)},
+		"spells/fire-bolt.json":      {Data: []byte(`{"index": "fire-bolt", "level": 0}`)},
+		"spells/fireball.json":       {Data: []byte(`{"index": "fireball", "level": 3}`)},
+		"classes/wizard/spells.json": {Data: []byte(list)},
+		// a recorded response for a query wins over the computed one
+		"spells@level=9.json": {Data: []byte(`{"count": 1, "results": [{"index": "wish", "name": "Wish"}]}`)},
+	})
+	defer server.Close()
+
+	client := server.NewClient()
+
+	level := 3
+	spells, err := client.ListSpells(&dnd5e.ListSpellsInput{Level: &level})
+	assert.NoError(t, err)
+	assert.Len(t, spells, 1)
+	assert.Equal(t, "fireball", spells[0].Key)
+
+	level = 0
+	spells, err = client.ListSpells(&dnd5e.ListSpellsInput{Class: "wizard", Level: &level})
+	assert.NoError(t, err)
+	assert.Len(t, spells, 1)
+	assert.Equal(t, "fire-bolt", spells[0].Key)
+
+	level = 9
+	spells, err = client.ListSpells(&dnd5e.ListSpellsInput{Level: &level})
+	assert.NoError(t, err)
+	assert.Len(t, spells, 1)
+	assert.Equal(t, "wish", spells[0].Key)
+
+	assert.Equal(t, []string{
+		"/api/spells?level=3",
+		"/api/spells?level=0",
+		"/api/classes/wizard/spells",
+		"/api/spells?level=9",
+	}, server.Requests())
+}
@@ -0,0 +1,73 @@
+package dnd5e
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors that the errors returned by the client can be matched against
+// with errors.Is.
+var (
+	// ErrNotFound matches a 404 response, i.e. the requested key does not exist.
+	ErrNotFound = errors.New("dnd5e: resource not found")
+	// ErrRateLimited matches a 429 response from the API.
+	ErrRateLimited = errors.New("dnd5e: rate limited")
+	// ErrServer matches any 5xx response from the API.
+	ErrServer = errors.New("dnd5e: server error")
+	// ErrDecode matches a response body that could not be decoded.
+	ErrDecode = errors.New("dnd5e: could not decode response")
+	// ErrUnsupportedReference matches a reference whose Type the client has no
+	// lookup for, such as "traits" or "ability-scores".
+	ErrUnsupportedReference = errors.New("dnd5e: unsupported reference type")
+)
+
+// StatusError is returned when the API answers with a status other than 200 OK.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	Kind       ResourceKind
+	// Key is the requested resource key, empty for list endpoints.
+	Key string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// Is reports whether the status code falls into the class described by target.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// DecodeError is returned when a response body can't be decoded into the
+// expected result. Error returns the decoder's message unchanged.
+type DecodeError struct {
+	URL  string
+	Kind ResourceKind
+	// Key is the requested resource key, empty for list endpoints.
+	Key string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDecode.
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
@@ -0,0 +1,111 @@
+package dnd5e
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	fileStoreExtension = ".cache"
+	// fileStoreVersion is bumped whenever the file layout changes, so files
+	// left behind by an older version are discarded instead of misread
+	fileStoreVersion    = 1
+	fileStoreHeaderSize = 9
+)
+
+// FileStore is a CacheStore keeping one file per key in a directory, which
+// makes it suitable for persisting a cache across restarts. Each file starts
+// with a format version and the expiry time, followed by the value; expired or
+// unreadable files are removed when they are read.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("dir is required")
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// path maps a cache key such as "list:spells:class:wizard" to a file name that
+// is safe on every platform
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, url.QueryEscape(key)+fileStoreExtension)
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(data) < fileStoreHeaderSize || data[0] != fileStoreVersion {
+		return nil, false, s.Delete(ctx, key)
+	}
+
+	expiresAt := int64(binary.BigEndian.Uint64(data[1:fileStoreHeaderSize]))
+	if expiresAt != 0 && time.Now().UnixNano() > expiresAt {
+		return nil, false, s.Delete(ctx, key)
+	}
+
+	return data[fileStoreHeaderSize:], true, nil
+}
+
+func (s *FileStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+
+	data := make([]byte, fileStoreHeaderSize+len(value))
+	data[0] = fileStoreVersion
+	binary.BigEndian.PutUint64(data[1:], uint64(expiresAt))
+	copy(data[fileStoreHeaderSize:], value)
+
+	return writeFileAtomic(s.path(key), data)
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place, so readers never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
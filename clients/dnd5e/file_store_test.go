@@ -0,0 +1,175 @@
+package dnd5e
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileStore(t *testing.T) {
+	t.Run("requires a dir", func(t *testing.T) {
+		store, err := NewFileStore("")
+		assert.Nil(t, store)
+		assert.EqualError(t, err, "dir is required")
+	})
+
+	t.Run("creates the dir", func(t *testing.T) {
+		dir := t.TempDir() + "/cache/nested"
+
+		_, err := NewFileStore(dir)
+		assert.NoError(t, err)
+
+		info, err := os.Stat(dir)
+		assert.NoError(t, err)
+		assert.True(t, info.IsDir())
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		key := "list:spells:class:wizard:level:3"
+
+		_, found, err := store.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		assert.NoError(t, store.Set(ctx, key, []byte("spells"), time.Hour))
+
+		value, found, err := store.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("spells"), value)
+
+		assert.NoError(t, store.Delete(ctx, key))
+		assert.NoError(t, store.Delete(ctx, key))
+
+		_, found, _ = store.Get(ctx, key)
+		assert.False(t, found)
+	})
+
+	t.Run("keys with path separators stay in the dir", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewFileStore(dir)
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.Set(ctx, "class:../wizard", []byte("wizard"), 0))
+
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		value, found, _ := store.Get(ctx, "class:../wizard")
+		assert.True(t, found)
+		assert.Equal(t, []byte("wizard"), value)
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewFileStore(dir)
+		assert.NoError(t, err)
+
+		store.Set(ctx, "monster:goblin", []byte("goblin"), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found, err := store.Get(ctx, "monster:goblin")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("backs a cached client", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		level := &entities.Level{Level: 3, Key: "wizard-3", ClassSpecific: &entities.WizardSpecific{ArcaneRecoveryLevels: 2}}
+		mockClient := new(MockClient)
+		mockClient.On("GetClassLevel", "wizard", 3).Return(level, nil)
+
+		first, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+		second, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, Store: store})
+		assert.NoError(t, err)
+
+		first.GetClassLevel("wizard", 3)
+
+		result, err := second.GetClassLevel("wizard", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, level, result)
+		mockClient.AssertNumberOfCalls(t, "GetClassLevel", 1)
+	})
+}
+
+func TestCachedClient_PersistDir(t *testing.T) {
+	goblin := &entities.Monster{Key: "goblin", Name: "Goblin"}
+
+	t.Run("starts warm after a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil)
+
+		before, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, PersistDir: dir})
+		assert.NoError(t, err)
+		before.GetMonster("goblin")
+		before.Close()
+
+		after, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, PersistDir: dir})
+		assert.NoError(t, err)
+		defer after.Close()
+
+		result, err := after.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, goblin, result)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+	})
+
+	t.Run("refetches unreadable files", func(t *testing.T) {
+		dir := t.TempDir()
+		mockClient := new(MockClient)
+		mockClient.On("GetMonster", "goblin").Return(goblin, nil)
+
+		store, err := NewFileStore(dir)
+		assert.NoError(t, err)
+		store.Set(context.Background(), "monster:goblin", []byte("not gob"), 0)
+
+		cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{Client: mockClient, TTL: time.Hour, PersistDir: dir})
+		assert.NoError(t, err)
+
+		result, err := cachedClient.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, goblin, result)
+		mockClient.AssertNumberOfCalls(t, "GetMonster", 1)
+
+		// the refetched value replaced the bad file
+		value, found, _ := store.Get(context.Background(), "monster:goblin")
+		assert.True(t, found)
+		_, err = decodeCacheEntry(value)
+		assert.NoError(t, err)
+	})
+
+	t.Run("discards files from another format version", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewFileStore(dir)
+		assert.NoError(t, err)
+
+		path := store.path("monster:goblin")
+		assert.NoError(t, os.WriteFile(path, make([]byte, fileStoreHeaderSize+4), 0o644))
+
+		_, found, err := store.Get(context.Background(), "monster:goblin")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,160 @@
+package dnd5e
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fixtureStatusExtension marks the file recording the status of a response
+// that wasn't 200 OK; the body is stored next to it as usual
+const fixtureStatusExtension = ".status"
+
+// Recorder is an httpIface that passes every request to another client and
+// saves the response under a directory, keyed by URL path: the response for
+// /api/monsters/goblin is saved as monsters/goblin.json, and one for
+// /api/spells?level=3 as spells@level=3.json. Use it with a Replayer to
+// regenerate test fixtures from the live API.
+type Recorder struct {
+	client httpIface
+	dir    string
+}
+
+// NewRecorder creates a Recorder saving the responses of client under dir
+func NewRecorder(client httpIface, dir string) (*Recorder, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+
+	if dir == "" {
+		return nil, errors.New("dir is required")
+	}
+
+	return &Recorder{client: client, dir: dir}, nil
+}
+
+func (r *Recorder) Get(rawURL string) (*http.Response, error) {
+	name, err := fixtureName(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.save(name, resp.StatusCode, body)
+	if err != nil {
+		return nil, fmt.Errorf("recording %s: %w", rawURL, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (r *Recorder) save(name string, status int, body []byte) error {
+	file := filepath.Join(r.dir, filepath.FromSlash(name))
+
+	err := os.MkdirAll(filepath.Dir(file), 0o755)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(file+".json", body)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusOK {
+		err = os.Remove(file + fixtureStatusExtension)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	return writeFileAtomic(file+fixtureStatusExtension, []byte(strconv.Itoa(status)))
+}
+
+// Replayer is an httpIface serving the responses saved by a Recorder, so the
+// client can run against recorded data without network access. Requests
+// without a recording fail with an error wrapping fs.ErrNotExist.
+type Replayer struct {
+	fsys fs.FS
+}
+
+// NewReplayer creates a Replayer serving the recordings in fsys, e.g.
+// os.DirFS("testdata/recorded")
+func NewReplayer(fsys fs.FS) (*Replayer, error) {
+	if fsys == nil {
+		return nil, errors.New("fsys is required")
+	}
+
+	return &Replayer{fsys: fsys}, nil
+}
+
+func (r *Replayer) Get(rawURL string) (*http.Response, error) {
+	name, err := fixtureName(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := fs.ReadFile(r.fsys, name+".json")
+	if err != nil {
+		return nil, fmt.Errorf("no recorded response for %s: %w", rawURL, err)
+	}
+
+	status := http.StatusOK
+	if data, err := fs.ReadFile(r.fsys, name+fixtureStatusExtension); err == nil {
+		status, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("reading recorded status for %s: %w", rawURL, err)
+		}
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
+// fixtureName returns the name a response for rawURL is recorded under,
+// without extension: its path relative to /api/, plus its query if any
+func fixtureName(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimPrefix(u.Path, "/")
+	name = strings.TrimPrefix(name, "api/")
+	if name == "" || path.Clean(name) != name || !fs.ValidPath(name) {
+		return "", fmt.Errorf("can't record %s: unsupported path", rawURL)
+	}
+
+	if u.RawQuery != "" {
+		// Encode sorts the parameters, so equivalent queries share a file
+		name += "@" + u.Query().Encode()
+	}
+
+	return name, nil
+}
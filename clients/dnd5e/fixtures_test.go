@@ -0,0 +1,177 @@
+package dnd5e
+
+import (
+	"flag"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var record = flag.Bool("record", false, "record the fixtures in testdata/recorded from the live API")
+
+const recordedFixtures = "../../testdata/recorded"
+
+// newRecordedClient replays testdata/recorded, or with -record refreshes it
+// from the live API
+func newRecordedClient(t *testing.T) Interface {
+	var client httpIface
+	if *record {
+		recorder, err := NewRecorder(&http.Client{Timeout: 30 * time.Second}, recordedFixtures)
+		assert.NoError(t, err)
+		client = recorder
+	} else {
+		replayer, err := NewReplayer(os.DirFS(recordedFixtures))
+		assert.NoError(t, err)
+		client = replayer
+	}
+
+	api, err := NewDND5eAPI(&DND5eAPIConfig{Client: client})
+	assert.NoError(t, err)
+
+	return api
+}
+
+func TestDND5eAPI_Recorded(t *testing.T) {
+	client := newRecordedClient(t)
+
+	t.Run("GetRace", func(t *testing.T) {
+		race, err := client.GetRace("dwarf")
+		assert.NoError(t, err)
+		assert.Equal(t, "Dwarf", race.Name)
+		assert.Equal(t, 25, race.Speed)
+	})
+
+	t.Run("ListMonsters", func(t *testing.T) {
+		monsters, err := client.ListMonsters()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, monsters)
+	})
+
+	t.Run("GetMonster", func(t *testing.T) {
+		monster, err := client.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Goblin", monster.Name)
+		assert.Equal(t, 7, monster.HitPoints)
+	})
+
+	t.Run("GetMonster not found", func(t *testing.T) {
+		_, err := client.GetMonster("beholder-zombie-king")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("GetSpell", func(t *testing.T) {
+		spell, err := client.GetSpell("burning-hands")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, spell.SpellLevel)
+	})
+
+	t.Run("GetClassLevel", func(t *testing.T) {
+		level, err := client.GetClassLevel("wizard", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, level.ProfBonus)
+	})
+
+	t.Run("ListDamageTypes", func(t *testing.T) {
+		damageTypes, err := client.ListDamageTypes()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, damageTypes)
+	})
+
+	t.Run("GetDamageType", func(t *testing.T) {
+		damageType, err := client.GetDamageType("acid")
+		assert.NoError(t, err)
+		assert.Equal(t, "Acid", damageType.Name)
+	})
+
+	t.Run("ListSkills", func(t *testing.T) {
+		skills, err := client.ListSkills()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, skills)
+	})
+
+	t.Run("GetSkill", func(t *testing.T) {
+		skill, err := client.GetSkill("acrobatics")
+		assert.NoError(t, err)
+		assert.Equal(t, "dex", skill.AbilityScore.Key)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.String() {
+		case "/api/monsters/goblin":
+			w.Write([]byte(`{"index": "goblin", "name": "Goblin"}`))
+		case "/api/spells?level=3":
+			w.Write([]byte(`{"count": 1, "results": [{"index": "fireball", "name": "Fireball"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(server.Client(), dir)
+	assert.NoError(t, err)
+
+	recording, err := NewDND5eAPI(&DND5eAPIConfig{Client: recorder, BaseURL: server.URL + "/api/"})
+	assert.NoError(t, err)
+
+	monster, err := recording.GetMonster("goblin")
+	assert.NoError(t, err)
+	level := 3
+	spells, err := recording.ListSpells(&ListSpellsInput{Level: &level})
+	assert.NoError(t, err)
+	_, err = recording.GetMonster("beholder")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var files []string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	assert.ElementsMatch(t, []string{
+		"monsters/goblin.json",
+		"spells@level=3.json",
+		"monsters/beholder.json",
+		"monsters/beholder.status",
+	}, files)
+
+	// the recordings replay the same results, from any base URL
+	replayer, err := NewReplayer(os.DirFS(dir))
+	assert.NoError(t, err)
+
+	replaying, err := NewDND5eAPI(&DND5eAPIConfig{Client: replayer})
+	assert.NoError(t, err)
+
+	replayed, err := replaying.GetMonster("goblin")
+	assert.NoError(t, err)
+	assert.Equal(t, monster, replayed)
+
+	replayedSpells, err := replaying.ListSpells(&ListSpellsInput{Level: &level})
+	assert.NoError(t, err)
+	assert.Equal(t, spells, replayedSpells)
+
+	_, err = replaying.GetMonster("beholder")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = replaying.GetMonster("mimic")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestNewRecorder(t *testing.T) {
+	_, err := NewRecorder(nil, t.TempDir())
+	assert.EqualError(t, err, "client is required")
+
+	_, err = NewRecorder(http.DefaultClient, "")
+	assert.EqualError(t, err, "dir is required")
+}
@@ -0,0 +1,391 @@
+package dnd5e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+const graphQLURL = "https://www.dnd5eapi.co/graphql"
+
+// graphQLClassQuery selects a class with every level and the features granted
+// at each level, using the REST field names so the results decode into the
+// same types.
+const graphQLClassQuery = `query Class($index: String) {
+  class(index: $index) {
+    index
+    name
+    hit_die
+    proficiencies { index name }
+    saving_throws { index name }
+    starting_equipment { quantity equipment { index name } }
+    multi_classing { prerequisites { ability_score { index name } minimum_score } }
+    class_levels {
+      index
+      level
+      ability_score_bonuses
+      prof_bonus
+      class { index name }
+      features { index name level class { index name } }
+      spellcasting {
+        cantrips_known
+        spells_known
+        spell_slots_level_1
+        spell_slots_level_2
+        spell_slots_level_3
+        spell_slots_level_4
+        spell_slots_level_5
+        spell_slots_level_6
+        spell_slots_level_7
+        spell_slots_level_8
+        spell_slots_level_9
+      }
+      class_specific {
+        ... on BarbarianSpecific { rage_count rage_damage_bonus brutal_critical_dice }
+        ... on BardSpecific { bardic_inspiration_die song_of_rest_die magical_secrets_max_5 magical_secrets_max_7 magical_secrets_max_9 }
+        ... on ClericSpecific { channel_divinity_charges destroy_undead_cr }
+        ... on DruidSpecific { wild_shape_max_cr wild_shape_swim wild_shape_fly }
+        ... on FighterSpecific { action_surges indomitable_uses extra_attacks }
+        ... on MonkSpecific { ki_points unarmored_movement martial_arts { dice_count dice_value } }
+        ... on PaladinSpecific { aura_range }
+        ... on RangerSpecific { favored_enemies favored_terrain }
+        ... on RogueSpecific { sneak_attack { dice_count dice_value } }
+        ... on SorcererSpecific { sorcery_points metamagic_known creating_spell_slots { spell_slot_level sorcery_point_cost } }
+        ... on WarlockSpecific { invocations_known mystic_arcanum_level_6 mystic_arcanum_level_7 mystic_arcanum_level_8 mystic_arcanum_level_9 }
+        ... on WizardSpecific { arcane_recovery_levels }
+      }
+    }
+  }
+}`
+
+// graphQLMonsterQuery selects a monster with its actions
+const graphQLMonsterQuery = `query Monster($index: String) {
+  monster(index: $index) {
+    index
+    name
+    size
+    type
+    alignment
+    armor_class { type value }
+    hit_points
+    hit_dice
+    hit_points_roll
+    speed { walk burrow climb fly swim }
+    strength
+    dexterity
+    constitution
+    intelligence
+    wisdom
+    charisma
+    proficiencies { value proficiency { index name } }
+    damage_vulnerabilities
+    damage_resistances
+    damage_immunities
+    condition_immunities { index name }
+    senses { blindsight darkvision tremorsense truesight passive_perception }
+    languages
+    challenge_rating
+    xp
+    image
+    actions {
+      name
+      desc
+      attack_bonus
+      damage { ... on Damage { damage_dice damage_type { index name } } }
+    }
+  }
+}`
+
+// GraphQLConfig configures a GraphQLClient
+type GraphQLConfig struct {
+	// API configures the HTTP client, retries and rate limiting. Queries are
+	// POSTed, so API.Client must implement Do(*http.Request) as *http.Client does.
+	API *DND5eAPIConfig
+	// URL is the GraphQL endpoint. It defaults to the dnd5eapi.co endpoint for
+	// API.RulesVersion.
+	URL string
+}
+
+// GraphQLClient fetches nested resources from the API's GraphQL endpoint in a
+// single request where the REST client needs one request per resource.
+//
+// GraphQL results carry no URLs, so the Type of each reference is set from
+// the field it appears in. Some class and feature fields are not fetched; see
+// GetClassWithLevels.
+type GraphQLClient struct {
+	api *dnd5eAPI
+	url string
+}
+
+// NewGraphQLClient creates a GraphQLClient
+func NewGraphQLClient(cfg *GraphQLConfig) (*GraphQLClient, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is required")
+	}
+
+	client, err := NewDND5eAPI(cfg.API)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := cfg.API.Client.(httpDoer); !ok {
+		return nil, errors.New("cfg.API.Client must implement Do(*http.Request)")
+	}
+
+	url := cfg.URL
+	if url == "" {
+		url = graphQLURL
+		if cfg.API.RulesVersion != "" {
+			url += "/" + string(cfg.API.RulesVersion)
+		}
+	}
+
+	return &GraphQLClient{
+		api: client.(*dnd5eAPI),
+		url: url,
+	}, nil
+}
+
+// ClassWithLevels is a class together with all of its levels and the features
+// they grant
+type ClassWithLevels struct {
+	Class *entities.Class
+	// Levels are ordered by level
+	Levels []*entities.Level
+	// Features are ordered by the level they are gained at
+	Features []*entities.Feature
+}
+
+// GraphQLError reports errors returned in a GraphQL response
+type GraphQLError struct {
+	URL      string
+	Kind     ResourceKind
+	Key      string
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql query failed: " + strings.Join(e.Messages, "; ")
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type graphQLClass struct {
+	classResult
+	ClassLevels []*graphQLLevel `json:"class_levels"`
+}
+
+type graphQLLevel struct {
+	levelResult
+	Features []*featureResult `json:"features"`
+}
+
+// GetClassWithLevels fetches the class with the given key, all of its levels
+// and their features in one request. The query leaves out the fields built
+// from choice option sets and class-wide spellcasting, so these are always
+// empty and need the REST client (GetClassContext, GetFeatureContext):
+//
+//   - Class.StartingEquipmentOptions, whose equipment-category option sets
+//     take one REST request per set
+//   - Class.ProficiencyChoices
+//   - Class.Spellcasting
+//   - Features[].FeatureSpecific (subfeature options)
+//   - Features[].Invocations
+func (c *GraphQLClient) GetClassWithLevels(ctx context.Context, key string) (*ClassWithLevels, error) {
+	if key == "" {
+		return nil, errors.New("key is required")
+	}
+
+	response := struct {
+		Class *graphQLClass `json:"class"`
+	}{}
+
+	err := c.query(ctx, ResourceClass, key, graphQLClassQuery, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Class == nil {
+		return nil, newHTTPStatusError(http.StatusNotFound, ResourceClass, key, c.url)
+	}
+
+	class := &response.Class.classResult
+	setReferenceTypes("proficiencies", class.Proficiencies...)
+	setReferenceTypes("ability-scores", class.SavingThrows...)
+	for _, e := range class.StartingEquipment {
+		if e != nil {
+			setReferenceTypes("equipment", e.Equipment)
+		}
+	}
+
+	if class.MultiClassing != nil {
+		for _, p := range class.MultiClassing.Prerequisites {
+			if p != nil {
+				setReferenceTypes("ability-scores", p.AbilityScore)
+			}
+		}
+	}
+
+	out := &ClassWithLevels{
+		Class: classResultToClass(class, nil),
+	}
+
+	levels := make([]*graphQLLevel, 0, len(response.Class.ClassLevels))
+	for _, l := range response.Class.ClassLevels {
+		if l != nil {
+			levels = append(levels, l)
+		}
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		return levels[i].Level < levels[j].Level
+	})
+
+	seen := make(map[string]bool)
+	for _, l := range levels {
+		setReferenceTypes("classes", l.Class)
+
+		featureRefs := make([]*referenceItem, 0, len(l.Features))
+		for _, f := range l.Features {
+			if f == nil {
+				continue
+			}
+
+			setReferenceTypes("classes", f.Class)
+			featureRefs = append(featureRefs, &referenceItem{Index: f.Index, Name: f.Name, URL: "/api/features/" + f.Index})
+
+			if !seen[f.Index] {
+				seen[f.Index] = true
+				out.Features = append(out.Features, featureResultToFeature(f))
+			}
+		}
+
+		l.levelResult.Features = featureRefs
+		out.Levels = append(out.Levels, levelResultToLevel(&l.levelResult))
+	}
+
+	return out, nil
+}
+
+// GetMonsterWithActions fetches the monster with the given key, including its
+// actions, in one request
+func (c *GraphQLClient) GetMonsterWithActions(ctx context.Context, key string) (*entities.Monster, error) {
+	if key == "" {
+		return nil, errors.New("key is required")
+	}
+
+	response := struct {
+		Monster *monsterResult `json:"monster"`
+	}{}
+
+	err := c.query(ctx, ResourceMonster, key, graphQLMonsterQuery, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	monster := response.Monster
+	if monster == nil {
+		return nil, newHTTPStatusError(http.StatusNotFound, ResourceMonster, key, c.url)
+	}
+
+	setReferenceTypes("conditions", monster.ConditionImmunities...)
+	for _, p := range monster.Proficiencies {
+		if p != nil {
+			setReferenceTypes("proficiencies", p.Proficiency)
+		}
+	}
+
+	for _, a := range monster.MonsterActions {
+		if a == nil {
+			continue
+		}
+
+		for _, d := range a.Damage {
+			if d != nil {
+				setReferenceTypes("damage-types", d.DamageType)
+			}
+		}
+	}
+
+	return monsterResultToMonster(monster), nil
+}
+
+// query POSTs query with key as its $index variable and decodes the data
+// into out, retrying, rate limiting and passing it through middleware like any
+// other request
+func (c *GraphQLClient) query(ctx context.Context, kind ResourceKind, key, query string, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{
+		Query:     query,
+		Variables: map[string]interface{}{"index": key},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.api.send(ctx, kind, key, c.url, func(attempt int) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		return c.api.do(&Request{HTTP: req, Kind: kind, Key: key, Attempt: attempt})
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	response := graphQLResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return newDecodeError(kind, key, c.url, err)
+	}
+
+	if len(response.Errors) > 0 {
+		messages := make([]string, len(response.Errors))
+		for i, e := range response.Errors {
+			messages[i] = e.Message
+		}
+
+		return &GraphQLError{URL: c.url, Kind: kind, Key: key, Messages: messages}
+	}
+
+	if len(response.Data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(response.Data, out)
+	if err != nil {
+		return newDecodeError(kind, key, c.url, err)
+	}
+
+	return nil
+}
+
+// setReferenceTypes gives references from a GraphQL result the URL the REST
+// API would have returned, so urlToType can derive their Type
+func setReferenceTypes(path string, items ...*referenceItem) {
+	for _, item := range items {
+		if item != nil && item.URL == "" {
+			item.URL = "/api/" + path + "/" + item.Index
+		}
+	}
+}
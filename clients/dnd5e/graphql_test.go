@@ -0,0 +1,166 @@
+package dnd5e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGraphQLServer answers every query with body and records the requests
+func newGraphQLServer(t *testing.T, body string, requests *[]graphQLRequest) *GraphQLClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		request := graphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*requests = append(*requests, request)
+
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewGraphQLClient(&GraphQLConfig{
+		API: &DND5eAPIConfig{Client: server.Client()},
+		URL: server.URL + "/graphql",
+	})
+	assert.NoError(t, err)
+
+	return client
+}
+
+func TestGraphQLClient_GetClassWithLevels(t *testing.T) {
+	var requests []graphQLRequest
+	client := newGraphQLServer(t, `{"data": {"class": {
+		"index": "wizard", "name": "Wizard", "hit_die": 6,
+		"saving_throws": [{"index": "int", "name": "INT"}, {"index": "wis", "name": "WIS"}],
+		"class_levels": [
+			{"index": "wizard-2", "level": 2, "prof_bonus": 2, "class": {"index": "wizard", "name": "Wizard"},
+				"features": [{"index": "arcane-tradition", "name": "Arcane Tradition", "level": 2, "class": {"index": "wizard", "name": "Wizard"}}],
+				"spellcasting": {"cantrips_known": 3, "spell_slots_level_1": 3},
+				"class_specific": {"arcane_recovery_levels": 1}},
+			{"index": "wizard-1", "level": 1, "prof_bonus": 2, "class": {"index": "wizard", "name": "Wizard"},
+				"features": [
+					{"index": "spellcasting-wizard", "name": "Spellcasting: Wizard", "level": 1, "class": {"index": "wizard", "name": "Wizard"}},
+					{"index": "arcane-recovery", "name": "Arcane Recovery", "level": 1, "class": {"index": "wizard", "name": "Wizard"}}],
+				"spellcasting": {"cantrips_known": 3, "spell_slots_level_1": 2},
+				"class_specific": {"arcane_recovery_levels": 1}}
+		]}}}`, &requests)
+
+	actual, err := client.GetClassWithLevels(context.Background(), "wizard")
+	assert.NoError(t, err)
+
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, graphQLClassQuery, requests[0].Query)
+		assert.Equal(t, map[string]interface{}{"index": "wizard"}, requests[0].Variables)
+	}
+
+	assert.Equal(t, "Wizard", actual.Class.Name)
+	assert.Equal(t, 6, actual.Class.HitDie)
+	assert.Equal(t, &entities.ReferenceItem{Key: "int", Name: "INT", Type: "ability-scores"}, actual.Class.SavingThrows[0])
+	// option sets and class spellcasting aren't queried; see GetClassWithLevels
+	for _, field := range []string{"starting_equipment_options", "proficiency_choices", "spellcasting_ability", "feature_specific", "invocations {"} {
+		assert.NotContains(t, graphQLClassQuery, field)
+	}
+	assert.Nil(t, actual.Class.StartingEquipmentOptions)
+	assert.Empty(t, actual.Class.ProficiencyChoices)
+	assert.Nil(t, actual.Class.Spellcasting)
+
+	if assert.Len(t, actual.Levels, 2) {
+		assert.Equal(t, 1, actual.Levels[0].Level)
+		assert.Equal(t, 2, actual.Levels[0].SpellCasting.SpellSlotsLevel1)
+		assert.Equal(t, &entities.ReferenceItem{Key: "spellcasting-wizard", Name: "Spellcasting: Wizard", Type: "features"}, actual.Levels[0].Features[0])
+		assert.Equal(t, &entities.WizardSpecific{ArcaneRecoveryLevels: 1}, actual.Levels[0].ClassSpecific)
+		assert.Equal(t, 2, actual.Levels[1].Level)
+	}
+
+	if assert.Len(t, actual.Features, 3) {
+		assert.Equal(t, "spellcasting-wizard", actual.Features[0].Key)
+		assert.Equal(t, "arcane-tradition", actual.Features[2].Key)
+		assert.Equal(t, &entities.ReferenceItem{Key: "wizard", Name: "Wizard", Type: "classes"}, actual.Features[2].Class)
+		for _, feature := range actual.Features {
+			assert.Nil(t, feature.FeatureSpecific)
+			assert.Empty(t, feature.Invocations)
+		}
+	}
+}
+
+func TestGraphQLClient_GetMonsterWithActions(t *testing.T) {
+	var requests []graphQLRequest
+	client := newGraphQLServer(t, `{"data": {"monster": {
+		"index": "goblin", "name": "Goblin", "armor_class": [{"type": "armor", "value": 15}],
+		"hit_points": 7, "challenge_rating": 0.25, "xp": 50,
+		"condition_immunities": [],
+		"actions": [{"name": "Scimitar", "desc": "Melee Weapon Attack", "attack_bonus": 4,
+			"damage": [{"damage_dice": "1d6+2", "damage_type": {"index": "slashing", "name": "Slashing"}}]}]
+		}}}`, &requests)
+
+	actual, err := client.GetMonsterWithActions(context.Background(), "goblin")
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+
+	assert.Equal(t, "Goblin", actual.Name)
+	assert.Equal(t, 15, actual.ArmorClass)
+	if assert.Len(t, actual.MonsterActions, 1) {
+		assert.Equal(t, "Scimitar", actual.MonsterActions[0].Name)
+		assert.Equal(t, &entities.ReferenceItem{Key: "slashing", Name: "Slashing", Type: "damage-types"}, actual.MonsterActions[0].Damage[0].DamageType)
+	}
+}
+
+func TestGraphQLClient_Errors(t *testing.T) {
+	t.Run("a null result is not found", func(t *testing.T) {
+		var requests []graphQLRequest
+		client := newGraphQLServer(t, `{"data": {"monster": null}}`, &requests)
+
+		_, err := client.GetMonsterWithActions(context.Background(), "not-a-goblin")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("reports GraphQL errors", func(t *testing.T) {
+		var requests []graphQLRequest
+		client := newGraphQLServer(t, `{"data": null, "errors": [{"message": "Cannot query field"}, {"message": "Bad index"}]}`, &requests)
+
+		_, err := client.GetClassWithLevels(context.Background(), "wizard")
+
+		var gqlErr *GraphQLError
+		if assert.ErrorAs(t, err, &gqlErr) {
+			assert.Equal(t, ResourceClass, gqlErr.Kind)
+			assert.Equal(t, "wizard", gqlErr.Key)
+			assert.True(t, strings.HasSuffix(gqlErr.URL, "/graphql"))
+		}
+		assert.EqualError(t, err, "graphql query failed: Cannot query field; Bad index")
+	})
+
+	t.Run("requires a key", func(t *testing.T) {
+		var requests []graphQLRequest
+		client := newGraphQLServer(t, `{}`, &requests)
+
+		_, err := client.GetClassWithLevels(context.Background(), "")
+		assert.EqualError(t, err, "key is required")
+		assert.Empty(t, requests)
+	})
+}
+
+func TestNewGraphQLClient(t *testing.T) {
+	t.Run("requires a client that can Do requests", func(t *testing.T) {
+		_, err := NewGraphQLClient(&GraphQLConfig{API: &DND5eAPIConfig{Client: &mockHTTPClient{}}})
+		assert.EqualError(t, err, "cfg.API.Client must implement Do(*http.Request)")
+	})
+
+	t.Run("defaults to the versioned endpoint", func(t *testing.T) {
+		client, err := NewGraphQLClient(&GraphQLConfig{API: &DND5eAPIConfig{Client: http.DefaultClient, RulesVersion: Rules2024}})
+		assert.NoError(t, err)
+		assert.Equal(t, graphQLURL+"/2024", client.url)
+	})
+
+	t.Run("requires cfg", func(t *testing.T) {
+		_, err := NewGraphQLClient(nil)
+		assert.EqualError(t, err, "cfg is required")
+	})
+}
@@ -1,6 +1,7 @@
 package dnd5e
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/fadedpez/dnd5e-api/entities"
@@ -31,10 +32,75 @@ type Interface interface {
 	GetBackground(key string) (*entities.Background, error)
 }
 
+// BatchInterface fetches several resources of one kind concurrently, for
+// instance to resolve the ReferenceItems returned by a list. Results are in
+// the order of keys; keys that failed are reported in a *BatchError. It is
+// optional: the clients returned by NewDND5eAPI and NewCachedClient implement
+// it, and Batch does the same for any Interface.
+type BatchInterface interface {
+	GetMonsters(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Monster, error)
+	GetSpells(ctx context.Context, keys []string, opts *BatchOptions) ([]*entities.Spell, error)
+}
+
+// ContextInterface mirrors Interface with methods that take a context.Context,
+// so callers can cancel a lookup or put a deadline on it. The context is passed
+// down to the outgoing HTTP request. It is optional: the clients returned by
+// NewDND5eAPI, NewCachedClient and NewOfflineClient implement it, and wrappers
+// such as CachedClient fall back to the plain methods for clients that don't.
+type ContextInterface interface {
+	ListRacesContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetRaceContext(ctx context.Context, key string) (*entities.Race, error)
+	ListEquipmentContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetEquipmentContext(ctx context.Context, key string) (EquipmentInterface, error)
+	ListClassesContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetClassContext(ctx context.Context, key string) (*entities.Class, error)
+	ListSpellsContext(ctx context.Context, input *ListSpellsInput) ([]*entities.ReferenceItem, error)
+	GetSpellContext(ctx context.Context, key string) (*entities.Spell, error)
+	ListFeaturesContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetFeatureContext(ctx context.Context, key string) (*entities.Feature, error)
+	ListSkillsContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetSkillContext(ctx context.Context, key string) (*entities.Skill, error)
+	ListMonstersContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	ListMonstersWithFilterContext(ctx context.Context, input *ListMonstersInput) ([]*entities.ReferenceItem, error)
+	GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error)
+	GetClassLevelContext(ctx context.Context, key string, level int) (*entities.Level, error)
+	GetProficiencyContext(ctx context.Context, key string) (*entities.Proficiency, error)
+	ListDamageTypesContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetDamageTypeContext(ctx context.Context, key string) (*entities.DamageType, error)
+	GetEquipmentCategoryContext(ctx context.Context, key string) (*entities.EquipmentCategory, error)
+	ListBackgroundsContext(ctx context.Context) ([]*entities.ReferenceItem, error)
+	GetBackgroundContext(ctx context.Context, key string) (*entities.Background, error)
+}
+
 type httpIface interface {
 	Get(url string) (*http.Response, error)
 }
 
+// httpDoer is implemented by clients such as *http.Client that can execute a
+// prepared request, which is how a request context reaches the transport.
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 type EquipmentInterface interface {
 	GetType() string
 }
+
+// ResourceKind identifies the kind of D&D 5e resource a request or cache entry
+// refers to.
+type ResourceKind string
+
+const (
+	ResourceRace              ResourceKind = "race"
+	ResourceEquipment         ResourceKind = "equipment"
+	ResourceClass             ResourceKind = "class"
+	ResourceSpell             ResourceKind = "spell"
+	ResourceFeature           ResourceKind = "feature"
+	ResourceSkill             ResourceKind = "skill"
+	ResourceMonster           ResourceKind = "monster"
+	ResourceClassLevel        ResourceKind = "class-level"
+	ResourceProficiency       ResourceKind = "proficiency"
+	ResourceDamageType        ResourceKind = "damage-type"
+	ResourceEquipmentCategory ResourceKind = "equipment-category"
+	ResourceBackground        ResourceKind = "background"
+)
@@ -0,0 +1,28 @@
+package dnd5e
+
+// Logger receives the clients' log messages. args are alternating keys and
+// values, as with log/slog, so a *slog.Logger can be used directly. Messages
+// carry the fields "url", "kind", "key" and "cache_key" where they apply.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// nopLogger discards everything; it is used when no Logger is configured
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// loggerOrNop returns l, or a Logger that discards everything if l is nil
+func loggerOrNop(l Logger) Logger {
+	if l == nil {
+		return nopLogger{}
+	}
+
+	return l
+}
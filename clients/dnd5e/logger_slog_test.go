@@ -0,0 +1,7 @@
+//go:build go1.21
+
+package dnd5e
+
+import "log/slog"
+
+var _ Logger = (*slog.Logger)(nil)
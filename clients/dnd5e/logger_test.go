@@ -0,0 +1,146 @@
+package dnd5e
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type logRecord struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger keeps every message it receives
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+func (l *recordingLogger) log(level, msg string, args []any) {
+	fields := make(map[string]interface{})
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[args[i].(string)] = args[i+1]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, logRecord{level: level, msg: msg, fields: fields})
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.log("debug", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.log("info", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.log("warn", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.log("error", msg, args) }
+
+func (l *recordingLogger) find(msg string) []logRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []logRecord
+	for _, r := range l.records {
+		if r.msg == msg {
+			out = append(out, r)
+		}
+	}
+
+	return out
+}
+
+func TestDND5eAPI_Logger(t *testing.T) {
+	server, _ := newFlakyServer(1, http.StatusServiceUnavailable, nil, `{"index": "goblin", "name": "Goblin"}`)
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client, err := NewDND5eAPI(&DND5eAPIConfig{
+		Client:  server.Client(),
+		BaseURL: server.URL + "/api/",
+		Retry:   testRetryPolicy(2),
+		Logger:  logger,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.GetMonster("goblin")
+	assert.NoError(t, err)
+
+	url := server.URL + "/api/monsters/goblin"
+
+	requests := logger.find("dnd5e api request")
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "debug", requests[0].level)
+		assert.Equal(t, url, requests[0].fields["url"])
+		assert.Equal(t, ResourceMonster, requests[0].fields["kind"])
+		assert.Equal(t, "goblin", requests[0].fields["key"])
+		assert.Equal(t, http.StatusServiceUnavailable, requests[0].fields["status"])
+		assert.Equal(t, http.StatusOK, requests[1].fields["status"])
+		assert.IsType(t, time.Duration(0), requests[1].fields["duration"])
+	}
+
+	retries := logger.find("retrying dnd5e api request")
+	if assert.Len(t, retries, 1) {
+		assert.Equal(t, "warn", retries[0].level)
+		assert.Equal(t, url, retries[0].fields["url"])
+		assert.Equal(t, 1, retries[0].fields["attempt"])
+	}
+}
+
+func TestCachedClient_Logger(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := &MockClient{}
+	mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+
+	store := NewMemoryStore()
+	store.Set(ctx, "monster:goblin", []byte("not a cache entry"), time.Hour)
+
+	logger := &recordingLogger{}
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client: mockClient,
+		TTL:    time.Hour,
+		Store:  store,
+		Logger: logger,
+	})
+	assert.NoError(t, err)
+
+	monster, err := cachedClient.GetMonster("goblin")
+	assert.NoError(t, err)
+	assert.Equal(t, "goblin", monster.Key)
+
+	records := logger.find("cache store entry could not be decoded")
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "warn", records[0].level)
+		assert.Equal(t, ResourceMonster, records[0].fields["kind"])
+		assert.Equal(t, "monster:goblin", records[0].fields["cache_key"])
+		assert.Error(t, records[0].fields["error"].(error))
+	}
+
+	t.Run("type mismatches are logged as errors", func(t *testing.T) {
+		cachedClient.addEntry("monster:goblin", &cacheEntry{data: "not a monster", kind: ResourceMonster, timestamp: time.Now()})
+		mockClient.On("GetMonster", "goblin").Return(&entities.Monster{Key: "goblin"}, nil).Once()
+
+		_, err := cachedClient.GetMonster("goblin")
+		assert.NoError(t, err)
+
+		records := logger.find("cache type mismatch")
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, "error", records[0].level)
+			assert.Equal(t, "monster:goblin", records[0].fields["cache_key"])
+			assert.Equal(t, "*entities.Monster", records[0].fields["expected"])
+			assert.Equal(t, "string", records[0].fields["got"])
+		}
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestLoggerOrNop(t *testing.T) {
+	assert.Equal(t, nopLogger{}, loggerOrNop(nil))
+
+	logger := &recordingLogger{}
+	assert.Same(t, logger, loggerOrNop(logger))
+}
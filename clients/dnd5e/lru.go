@@ -0,0 +1,168 @@
+package dnd5e
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// lruCache is a mutex guarded map of cache entries ordered by recency of use.
+// When maxEntries or maxBytes is exceeded the least recently used entries are
+// evicted. Zero limits mean unbounded.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *cacheEntry
+	size  int64
+}
+
+func newLRUCache(maxEntries int, maxBytes int64) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry for key and marks it as most recently used
+func (l *lruCache) get(key string) (*cacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	l.ll.MoveToFront(elem)
+	return elem.Value.(*lruItem).entry, true
+}
+
+// add stores entry under key and returns the entries evicted to make room
+func (l *lruCache) add(key string, entry *cacheEntry) []*lruItem {
+	var size int64
+	if l.maxBytes > 0 {
+		size = approximateSize(key, entry.data)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		item := elem.Value.(*lruItem)
+		l.bytes += size - item.size
+		item.entry = entry
+		item.size = size
+		l.ll.MoveToFront(elem)
+	} else {
+		l.items[key] = l.ll.PushFront(&lruItem{key: key, entry: entry, size: size})
+		l.bytes += size
+	}
+
+	var evicted []*lruItem
+	for l.overLimit() {
+		oldest := l.ll.Back()
+		if oldest == nil || oldest == l.ll.Front() {
+			// never evict the entry that was just added
+			break
+		}
+		evicted = append(evicted, l.removeElement(oldest))
+	}
+
+	return evicted
+}
+
+func (l *lruCache) overLimit() bool {
+	if l.maxEntries > 0 && l.ll.Len() > l.maxEntries {
+		return true
+	}
+
+	return l.maxBytes > 0 && l.bytes > l.maxBytes
+}
+
+// remove deletes key and reports whether it was present
+func (l *lruCache) remove(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok {
+		return false
+	}
+
+	l.removeElement(elem)
+	return true
+}
+
+// removeExpired deletes every entry older than the ttl returned for it and
+// returns the removed entries
+func (l *lruCache) removeExpired(ttl func(entry *cacheEntry) time.Duration) []*lruItem {
+	return l.removeIf(func(key string, entry *cacheEntry) bool {
+		return entry.isExpired(ttl(entry))
+	})
+}
+
+// removeIf deletes every entry for which fn returns true and returns the removed entries
+func (l *lruCache) removeIf(fn func(key string, entry *cacheEntry) bool) []*lruItem {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var removed []*lruItem
+	for elem := l.ll.Back(); elem != nil; {
+		prev := elem.Prev()
+		item := elem.Value.(*lruItem)
+		if fn(item.key, item.entry) {
+			removed = append(removed, l.removeElement(elem))
+		}
+		elem = prev
+	}
+
+	return removed
+}
+
+// each calls fn for every entry, from most to least recently used
+func (l *lruCache) each(fn func(key string, entry *cacheEntry)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for elem := l.ll.Front(); elem != nil; elem = elem.Next() {
+		item := elem.Value.(*lruItem)
+		fn(item.key, item.entry)
+	}
+}
+
+func (l *lruCache) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.ll.Len()
+}
+
+func (l *lruCache) removeElement(elem *list.Element) *lruItem {
+	item := l.ll.Remove(elem).(*lruItem)
+	delete(l.items, item.key)
+	l.bytes -= item.size
+
+	return item
+}
+
+// approximateSize estimates the memory held by a cached value from the size of
+// its JSON encoding. It is only computed when a byte budget is configured.
+func approximateSize(key string, data interface{}) int64 {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return int64(len(key))
+	}
+
+	return int64(len(key) + len(encoded))
+}
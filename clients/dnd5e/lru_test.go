@@ -0,0 +1,93 @@
+package dnd5e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	newEntry := func(data interface{}) *cacheEntry {
+		return &cacheEntry{data: data, timestamp: time.Now()}
+	}
+
+	t.Run("evicts the least recently used entry when full", func(t *testing.T) {
+		cache := newLRUCache(2, 0)
+
+		assert.Empty(t, cache.add("race:dwarf", newEntry("dwarf")))
+		assert.Empty(t, cache.add("race:elf", newEntry("elf")))
+
+		// touch dwarf so elf becomes the oldest
+		_, ok := cache.get("race:dwarf")
+		assert.True(t, ok)
+
+		evicted := cache.add("race:human", newEntry("human"))
+		assert.Equal(t, 1, len(evicted))
+		assert.Equal(t, "race:elf", evicted[0].key)
+		assert.Equal(t, 2, cache.len())
+
+		_, ok = cache.get("race:elf")
+		assert.False(t, ok)
+		_, ok = cache.get("race:dwarf")
+		assert.True(t, ok)
+		_, ok = cache.get("race:human")
+		assert.True(t, ok)
+	})
+
+	t.Run("replacing a key does not grow the cache", func(t *testing.T) {
+		cache := newLRUCache(2, 0)
+
+		cache.add("race:dwarf", newEntry("dwarf"))
+		cache.add("race:dwarf", newEntry("hill dwarf"))
+
+		assert.Equal(t, 1, cache.len())
+		entry, ok := cache.get("race:dwarf")
+		assert.True(t, ok)
+		assert.Equal(t, "hill dwarf", entry.data)
+	})
+
+	t.Run("evicts to stay within the byte budget", func(t *testing.T) {
+		// each entry is 11 bytes: a 5 byte key and a 6 byte JSON string
+		cache := newLRUCache(0, 25)
+
+		cache.add("key:1", newEntry("aaaa"))
+		cache.add("key:2", newEntry("bbbb"))
+		assert.Equal(t, 1, len(cache.add("key:3", newEntry("cccc"))))
+
+		_, ok := cache.get("key:1")
+		assert.False(t, ok)
+		assert.Equal(t, int64(22), cache.bytes)
+	})
+
+	t.Run("keeps an entry larger than the byte budget until the next add", func(t *testing.T) {
+		cache := newLRUCache(0, 5)
+
+		assert.Empty(t, cache.add("key:1", newEntry("too large")))
+		assert.Equal(t, 1, cache.len())
+	})
+
+	t.Run("removes expired entries", func(t *testing.T) {
+		cache := newLRUCache(0, 0)
+
+		cache.add("race:dwarf", &cacheEntry{data: "dwarf", timestamp: time.Now().Add(-time.Hour)})
+		cache.add("race:elf", newEntry("elf"))
+
+		assert.Equal(t, 1, len(cache.removeExpired(func(entry *cacheEntry) time.Duration {
+			return time.Minute
+		})))
+		assert.Equal(t, 1, cache.len())
+		_, ok := cache.get("race:elf")
+		assert.True(t, ok)
+	})
+
+	t.Run("removes a key", func(t *testing.T) {
+		cache := newLRUCache(0, 0)
+
+		cache.add("race:dwarf", newEntry("dwarf"))
+
+		assert.True(t, cache.remove("race:dwarf"))
+		assert.False(t, cache.remove("race:dwarf"))
+		assert.Equal(t, 0, cache.len())
+	})
+}
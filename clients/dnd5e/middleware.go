@@ -0,0 +1,120 @@
+package dnd5e
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Request is a single attempt at an API request, passed through the
+// Middleware chain
+type Request struct {
+	// HTTP is the outgoing request. Middleware may change its headers; each
+	// attempt, including retries, gets a fresh request.
+	HTTP *http.Request
+	// Kind and Key identify the resource being fetched. Key is empty for
+	// list requests.
+	Kind ResourceKind
+	Key  string
+	// Attempt is 1 for the first try and counts up across retries
+	Attempt int
+}
+
+// RoundTripFunc sends a request and returns the API's response
+type RoundTripFunc func(req *Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc that sends requests, e.g. to set headers
+// or time requests. It must call next to send the request; a middleware that
+// reads the response body has to replace it with one the client can still
+// read.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// RequestInfo describes a completed request attempt
+type RequestInfo struct {
+	Method  string
+	URL     string
+	Kind    ResourceKind
+	Key     string
+	Attempt int
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// SetHeader returns a Middleware that sets the header key to value on every
+// request, e.g. a User-Agent or the credentials for a self-hosted mirror
+func SetHeader(key, value string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *Request) (*http.Response, error) {
+			req.HTTP.Header.Set(key, value)
+			return next(req)
+		}
+	}
+}
+
+// ObserveRequests returns a Middleware that calls fn after every request
+// attempt, e.g. to log or record request timings
+func ObserveRequests(fn func(info RequestInfo)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+
+			info := RequestInfo{
+				Method:   req.HTTP.Method,
+				URL:      req.HTTP.URL.String(),
+				Kind:     req.Kind,
+				Key:      req.Key,
+				Attempt:  req.Attempt,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+			}
+
+			fn(info)
+			return resp, err
+		}
+	}
+}
+
+// chainMiddleware wraps send in middleware so that the first one runs first
+func chainMiddleware(middleware []Middleware, send RoundTripFunc) RoundTripFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		send = middleware[i](send)
+	}
+
+	return send
+}
+
+// roundTrip sends req with the underlying client, at the end of the
+// middleware chain. Clients that only expose Get can't send headers or other
+// methods, and are checked for cancellation before and after the call.
+func (c *dnd5eAPI) roundTrip(req *Request) (*http.Response, error) {
+	if doer, ok := c.client.(httpDoer); ok {
+		return doer.Do(req.HTTP)
+	}
+
+	if req.HTTP.Method != http.MethodGet {
+		return nil, fmt.Errorf("client can't send %s requests without Do(*http.Request)", req.HTTP.Method)
+	}
+
+	ctx := req.HTTP.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Get(req.HTTP.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
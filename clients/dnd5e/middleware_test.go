@@ -0,0 +1,123 @@
+package dnd5e
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDND5eAPI_Middleware(t *testing.T) {
+	t.Run("sets headers on every request", func(t *testing.T) {
+		var userAgent, auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			auth = r.Header.Get("Authorization")
+			w.Write([]byte(`{"index": "fireball", "name": "Fireball"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Middleware: []Middleware{
+				SetHeader("User-Agent", "my-app/1.0"),
+				SetHeader("Authorization", "Bearer secret"),
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = client.GetSpell("fireball")
+		assert.NoError(t, err)
+		assert.Equal(t, "my-app/1.0", userAgent)
+		assert.Equal(t, "Bearer secret", auth)
+	})
+
+	t.Run("runs middleware in order", func(t *testing.T) {
+		server := newJSONServer(t, map[string]string{"/api/races": `{"results": []}`})
+
+		var calls []string
+		record := func(name string) Middleware {
+			return func(next RoundTripFunc) RoundTripFunc {
+				return func(req *Request) (*http.Response, error) {
+					calls = append(calls, name+" before")
+					resp, err := next(req)
+					calls = append(calls, name+" after")
+					return resp, err
+				}
+			}
+		}
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:     server.Client(),
+			BaseURL:    server.URL + "/api/",
+			Middleware: []Middleware{record("a"), record("b")},
+		})
+
+		_, err := client.ListRaces()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a before", "b before", "b after", "a after"}, calls)
+	})
+
+	t.Run("observes every attempt", func(t *testing.T) {
+		server, _ := newFlakyServer(1, http.StatusServiceUnavailable, nil, `{"index": "goblin", "name": "Goblin"}`)
+		defer server.Close()
+
+		var infos []RequestInfo
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   testRetryPolicy(2),
+			Middleware: []Middleware{ObserveRequests(func(info RequestInfo) {
+				infos = append(infos, info)
+			})},
+		})
+
+		_, err := client.GetMonster("goblin")
+		assert.NoError(t, err)
+
+		if assert.Len(t, infos, 2) {
+			for i, info := range infos {
+				assert.Equal(t, http.MethodGet, info.Method)
+				assert.Equal(t, server.URL+"/api/monsters/goblin", info.URL)
+				assert.Equal(t, ResourceMonster, info.Kind)
+				assert.Equal(t, "goblin", info.Key)
+				assert.Equal(t, i+1, info.Attempt)
+				assert.Positive(t, info.Duration)
+				assert.NoError(t, info.Err)
+			}
+
+			assert.Equal(t, http.StatusServiceUnavailable, infos[0].StatusCode)
+			assert.Equal(t, http.StatusOK, infos[1].StatusCode)
+		}
+	})
+
+	t.Run("can answer without sending", func(t *testing.T) {
+		canned := func(next RoundTripFunc) RoundTripFunc {
+			return func(req *Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"index": "str", "name": "STR"}`)),
+				}, nil
+			}
+		}
+
+		client := &dnd5eAPI{client: &mockHTTPClient{}, baseURL: baserulzURL, middleware: []Middleware{canned}}
+
+		resp, err := client.get(context.Background(), ResourceProficiency, "str", baserulzURL+"proficiencies/str")
+		assert.NoError(t, err)
+		resp.Body.Close()
+	})
+}
+
+func TestDND5eAPI_RoundTrip_GetOnlyClient(t *testing.T) {
+	client := &dnd5eAPI{client: &mockHTTPClient{}, baseURL: baserulzURL}
+
+	req, _ := http.NewRequest(http.MethodPost, baserulzURL, strings.NewReader("{}"))
+	_, err := client.roundTrip(&Request{HTTP: req})
+	assert.EqualError(t, err, "client can't send POST requests without Do(*http.Request)")
+}
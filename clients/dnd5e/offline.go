@@ -0,0 +1,230 @@
+package dnd5e
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// offlineBaseURL is the base URL of clients reading from a snapshot; it is only
+// ever parsed by snapshotClient and never hits the network
+const offlineBaseURL = "offline:///api/"
+
+// OfflineConfig configures a client created with NewOfflineClient
+type OfflineConfig struct {
+	// FS holds an SRD snapshot laid out like the API, one JSON file per path:
+	// "monsters.json" for /api/monsters, "monsters/goblin.json" for
+	// /api/monsters/goblin, "classes/wizard/levels/3.json" and so on. Use
+	// os.DirFS for a snapshot on disk or an embed.FS to compile one in.
+	FS fs.FS
+	// RulesVersion is the rules version the snapshot was taken with, e.g.
+	// Rules2024 for one from SnapshotConfig.API.RulesVersion = Rules2024. It
+	// selects the same mapping as for the online client, such as reading races
+	// from "species".
+	RulesVersion RulesVersion
+}
+
+// NewOfflineClient creates a client that answers every call from a snapshot
+// instead of the API, so it works without network access. Filtered lists, such
+// as spells by level or monsters by challenge rating, are computed from the
+// snapshot. Resources missing from the snapshot return ErrNotFound.
+func NewOfflineClient(cfg *OfflineConfig) (Interface, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is required")
+	}
+
+	if cfg.FS == nil {
+		return nil, errors.New("cfg.FS is required")
+	}
+
+	// a snapshot stores paths relative to the versioned base URL, so the
+	// version segment NewDND5eAPI adds is stripped again when reading it
+	prefix := "/api/"
+	if cfg.RulesVersion != "" {
+		prefix += string(cfg.RulesVersion) + "/"
+	}
+
+	return NewDND5eAPI(&DND5eAPIConfig{
+		Client:       &snapshotClient{fsys: cfg.FS, prefix: prefix},
+		BaseURL:      offlineBaseURL,
+		RulesVersion: cfg.RulesVersion,
+	})
+}
+
+// snapshotClient is an httpIface answering requests from a snapshot
+type snapshotClient struct {
+	fsys fs.FS
+	// prefix is the URL path the snapshot's paths are relative to
+	prefix string
+}
+
+func (c *snapshotClient) Get(rawURL string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	status, body := serveSnapshot(c.fsys, strings.TrimPrefix(u.Path, c.prefix), u.Query())
+
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       &http.Request{Method: http.MethodGet, URL: u},
+	}, nil
+}
+
+// NewSnapshotHandler returns an http.Handler serving the API routes under
+// /api/ from a snapshot in the layout described in OfflineConfig, including
+// /api/spells?level= and /api/monsters?challenge_rating=. Responses recorded
+// for a specific query by a Recorder, e.g. "spells@level=3.json", take
+// precedence over computed ones. It is what dnd5etest's server runs, and can
+// be mounted in your own mux to serve a snapshot as a local mirror of the API.
+//
+// Missing files return 404, malformed filters 400, and methods other than GET
+// and HEAD 405, each with a JSON error body like the API's.
+func NewSnapshotHandler(fsys fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		status, body := http.StatusNotFound, []byte(`{"error":"Not found"}`)
+		if name := strings.TrimPrefix(r.URL.Path, "/api/"); name != r.URL.Path {
+			status, body = serveSnapshot(fsys, name, r.URL.Query())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+	})
+}
+
+// serveSnapshot answers an API request for name, a path relative to /api/ such
+// as "monsters/goblin", from fsys and returns the status code and JSON body
+func serveSnapshot(fsys fs.FS, name string, query url.Values) (int, []byte) {
+	var body []byte
+	var err error
+
+	if len(query) > 0 {
+		body, err = readSnapshotFile(fsys, name+"@"+query.Encode())
+		if err == nil {
+			return http.StatusOK, body
+		}
+	}
+
+	switch {
+	case name == "spells" && query.Has("level"):
+		body, err = filterSnapshotSpells(fsys, query.Get("level"))
+	case name == "monsters" && query.Has("challenge_rating"):
+		body, err = filterSnapshotMonsters(fsys, query.Get("challenge_rating"))
+	default:
+		body, err = readSnapshotFile(fsys, name)
+	}
+
+	switch {
+	case err == nil:
+		return http.StatusOK, body
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound, []byte(`{"error":"Not found"}`)
+	case errors.Is(err, errBadSnapshotQuery):
+		return http.StatusBadRequest, []byte(`{"error":"Bad request"}`)
+	default:
+		return http.StatusInternalServerError, []byte(`{"error":"Internal server error"}`)
+	}
+}
+
+var errBadSnapshotQuery = errors.New("invalid query")
+
+// readSnapshotFile reads the file holding the response for name. Names that
+// aren't canonical, e.g. containing "..", don't exist.
+func readSnapshotFile(fsys fs.FS, name string) ([]byte, error) {
+	if name == "" || path.Clean(name) != name || !fs.ValidPath(name) {
+		return nil, fs.ErrNotExist
+	}
+
+	return fs.ReadFile(fsys, name+".json")
+}
+
+// filterSnapshotSpells builds the response for /api/spells?level=
+func filterSnapshotSpells(fsys fs.FS, value string) ([]byte, error) {
+	level, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, errBadSnapshotQuery
+	}
+
+	return filterSnapshotList(fsys, "spells", func(data []byte) (bool, error) {
+		spell := spellResult{}
+		if err := json.Unmarshal(data, &spell); err != nil {
+			return false, err
+		}
+
+		return spell.SpellLevel == level, nil
+	})
+}
+
+// filterSnapshotMonsters builds the response for /api/monsters?challenge_rating=
+func filterSnapshotMonsters(fsys fs.FS, value string) ([]byte, error) {
+	cr, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, errBadSnapshotQuery
+	}
+
+	return filterSnapshotList(fsys, "monsters", func(data []byte) (bool, error) {
+		monster := monsterResult{}
+		if err := json.Unmarshal(data, &monster); err != nil {
+			return false, err
+		}
+
+		return monster.ChallengeRating == float32(cr), nil
+	})
+}
+
+// filterSnapshotList reads the list stored under name and keeps the items whose
+// detail file matches keep. Items without a detail file, as in a partial set of
+// fixtures, are left out.
+func filterSnapshotList(fsys fs.FS, name string, keep func(detail []byte) (bool, error)) ([]byte, error) {
+	data, err := readSnapshotFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	list := listResponse{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	out := listResponse{Results: make([]*referenceItem, 0)}
+	for _, item := range list.Results {
+		detail, err := readSnapshotFile(fsys, name+"/"+item.Index)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := keep(detail)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			out.Results = append(out.Results, item)
+		}
+	}
+
+	out.Count = len(out.Results)
+	return json.Marshal(out)
+}
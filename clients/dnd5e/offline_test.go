@@ -0,0 +1,210 @@
+package dnd5e
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSnapshot() fstest.MapFS {
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(data)}
+	}
+
+	return fstest.MapFS{
+		"monsters.json": file(`{"count": 2, "results": [
+			{"index": "goblin", "name": "Goblin", "url": "/api/monsters/goblin"},
+			{"index": "ogre", "name": "Ogre", "url": "/api/monsters/ogre"}]}`),
+		"monsters/goblin.json": file(`{"index": "goblin", "name": "Goblin", "hit_points": 7, "challenge_rating": 0.25}`),
+		"monsters/ogre.json":   file(`{"index": "ogre", "name": "Ogre", "hit_points": 59, "challenge_rating": 2}`),
+		"spells.json": file(`{"count": 2, "results": [
+			{"index": "fire-bolt", "name": "Fire Bolt", "url": "/api/spells/fire-bolt"},
+			{"index": "fireball", "name": "Fireball", "url": "/api/spells/fireball"}]}`),
+		"spells/fire-bolt.json": file(`{"index": "fire-bolt", "name": "Fire Bolt", "level": 0}`),
+		"spells/fireball.json":  file(`{"index": "fireball", "name": "Fireball", "level": 3}`),
+		"classes/wizard/spells.json": file(`{"count": 2, "results": [
+			{"index": "fire-bolt", "name": "Fire Bolt", "url": "/api/spells/fire-bolt"},
+			{"index": "fireball", "name": "Fireball", "url": "/api/spells/fireball"}]}`),
+		"classes/wizard/levels/3.json": file(`{"level": 3, "prof_bonus": 2, "index": "wizard-3",
+			"class": {"index": "wizard", "name": "Wizard", "url": "/api/classes/wizard"},
+			"class_specific": {"arcane_recovery_levels": 2}}`),
+	}
+}
+
+func TestNewOfflineClient(t *testing.T) {
+	t.Run("cfg is required", func(t *testing.T) {
+		_, err := NewOfflineClient(nil)
+		assert.EqualError(t, err, "cfg is required")
+	})
+
+	t.Run("cfg.FS is required", func(t *testing.T) {
+		_, err := NewOfflineClient(&OfflineConfig{})
+		assert.EqualError(t, err, "cfg.FS is required")
+	})
+}
+
+func TestOfflineClient(t *testing.T) {
+	client, err := NewOfflineClient(&OfflineConfig{FS: testSnapshot()})
+	assert.NoError(t, err)
+
+	t.Run("GetMonster", func(t *testing.T) {
+		monster, err := client.GetMonster("goblin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Goblin", monster.Name)
+		assert.Equal(t, 7, monster.HitPoints)
+	})
+
+	t.Run("ListMonstersWithFilter", func(t *testing.T) {
+		cr := 0.25
+		monsters, err := client.ListMonstersWithFilter(&ListMonstersInput{ChallengeRating: &cr})
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.ReferenceItem{{Key: "goblin", Name: "Goblin", Type: "monsters"}}, monsters)
+	})
+
+	t.Run("ListSpells by level", func(t *testing.T) {
+		level := 3
+		spells, err := client.ListSpells(&ListSpellsInput{Level: &level})
+		assert.NoError(t, err)
+		assert.Len(t, spells, 1)
+		assert.Equal(t, "fireball", spells[0].Key)
+	})
+
+	t.Run("ListSpells by class and level", func(t *testing.T) {
+		level := 0
+		spells, err := client.ListSpells(&ListSpellsInput{Class: "wizard", Level: &level})
+		assert.NoError(t, err)
+		assert.Len(t, spells, 1)
+		assert.Equal(t, "fire-bolt", spells[0].Key)
+	})
+
+	t.Run("GetClassLevel", func(t *testing.T) {
+		level, err := client.GetClassLevel("wizard", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, level.ProfBonus)
+		assert.Equal(t, &entities.WizardSpecific{ArcaneRecoveryLevels: 2}, level.ClassSpecific)
+	})
+
+	t.Run("missing resources are not found", func(t *testing.T) {
+		_, err := client.GetMonster("beholder")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = client.GetMonster("../monsters")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestOfflineClient_Rules2024(t *testing.T) {
+	server := newJSONServer(t, map[string]string{
+		"/api/2024/species": `{"count": 1, "results": [
+			{"index": "dwarf", "name": "Dwarf", "url": "/api/2024/species/dwarf"}]}`,
+		"/api/2024/species/dwarf": `{"index": "dwarf", "name": "Dwarf", "type": "Humanoid", "speed": 30,
+			"url": "/api/2024/species/dwarf"}`,
+	})
+
+	dir := t.TempDir()
+	// every other list is missing from the server, which only fails those lists
+	_, err := Snapshot(context.Background(), &SnapshotConfig{
+		API:     &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/", RulesVersion: Rules2024},
+		Dir:     dir,
+		Version: "srd-2024",
+	})
+	assert.Error(t, err)
+
+	fsys := os.DirFS(filepath.Join(dir, "srd-2024"))
+
+	client, err := NewOfflineClient(&OfflineConfig{FS: fsys, RulesVersion: Rules2024})
+	assert.NoError(t, err)
+
+	races, err := client.ListRaces()
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ReferenceItem{{Key: "dwarf", Name: "Dwarf", Type: "species"}}, races)
+
+	race, err := client.GetRace("dwarf")
+	assert.NoError(t, err)
+	assert.Equal(t, "Humanoid", race.CreatureType)
+
+	t.Run("needs the snapshot's rules version", func(t *testing.T) {
+		client, err := NewOfflineClient(&OfflineConfig{FS: fsys})
+		assert.NoError(t, err)
+
+		_, err = client.ListRaces()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		_, err := NewOfflineClient(&OfflineConfig{FS: fsys, RulesVersion: "1999"})
+		assert.EqualError(t, err, `cfg.RulesVersion "1999" is not supported`)
+	})
+}
+
+func TestNewSnapshotHandler(t *testing.T) {
+	fsys := testSnapshot()
+	fsys["spells@level=3.json"] = &fstest.MapFile{Data: []byte(`{"count": 0, "results": []}`)}
+
+	server := httptest.NewServer(NewSnapshotHandler(fsys))
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := server.Client().Get(server.URL + path)
+		if !assert.NoError(t, err) {
+			return 0, ""
+		}
+		defer resp.Body.Close()
+
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("serves snapshot files", func(t *testing.T) {
+		status, body := get("/api/monsters/goblin")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"index": "goblin", "name": "Goblin", "hit_points": 7, "challenge_rating": 0.25}`, body)
+	})
+
+	t.Run("filters lists", func(t *testing.T) {
+		status, body := get("/api/monsters?challenge_rating=2")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `"ogre"`)
+		assert.NotContains(t, body, `"goblin"`)
+	})
+
+	t.Run("prefers recorded queries", func(t *testing.T) {
+		status, body := get("/api/spells?level=3")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"count": 0, "results": []}`, body)
+	})
+
+	t.Run("rejects bad queries", func(t *testing.T) {
+		status, _ := get("/api/spells?level=high")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("returns 404 for missing files", func(t *testing.T) {
+		status, body := get("/api/monsters/dragon")
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.JSONEq(t, `{"error": "Not found"}`, body)
+
+		status, _ = get("/monsters/goblin")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("only allows GET and HEAD", func(t *testing.T) {
+		resp, err := server.Client().Post(server.URL+"/api/monsters/goblin", "application/json", nil)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+			assert.Equal(t, "GET, HEAD", resp.Header.Get("Allow"))
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package dnd5e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Run("allows a burst without waiting", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 5)
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}
+
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("spaces requests beyond the burst", func(t *testing.T) {
+		limiter := NewRateLimiter(20, 1)
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}
+
+		// 4 waits of 50ms after the initial token
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	})
+
+	t.Run("returns ctx error while waiting and hands the token back", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 1)
+		assert.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := limiter.Wait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		limiter.mu.Lock()
+		tokens := limiter.tokens
+		limiter.mu.Unlock()
+		assert.Greater(t, tokens, -0.5)
+	})
+
+	t.Run("a non-positive rate is unlimited", func(t *testing.T) {
+		limiter := NewRateLimiter(0, 1)
+
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}
+
+		limiter.mu.Lock()
+		tokens := limiter.tokens
+		limiter.mu.Unlock()
+		assert.Equal(t, float64(1), tokens)
+	})
+
+	t.Run("nil limiter never blocks", func(t *testing.T) {
+		var limiter *RateLimiter
+		assert.NoError(t, limiter.Wait(context.Background()))
+	})
+}
+
+func TestDND5eAPI_RateLimiter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"index": "goblin", "name": "Goblin"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewDND5eAPI(&DND5eAPIConfig{
+		Client:      server.Client(),
+		BaseURL:     server.URL + "/api/",
+		RateLimiter: NewRateLimiter(50, 2),
+	})
+	assert.NoError(t, err)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetMonster("goblin")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 2 requests go out immediately, the remaining 4 are spaced 20ms apart
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+}
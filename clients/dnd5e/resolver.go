@@ -0,0 +1,236 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+)
+
+var referenceItemType = reflect.TypeOf((*entities.ReferenceItem)(nil))
+
+// Resolver loads the entities that ReferenceItems point to, choosing the
+// lookup from the reference's Type. Wrap a CachedClient to avoid fetching the
+// same reference twice across calls.
+type Resolver struct {
+	client ContextInterface
+}
+
+// NewResolver creates a Resolver that looks references up through client. If
+// client doesn't implement ContextInterface, lookups only check ctx before
+// they start.
+func NewResolver(client Interface) *Resolver {
+	return &Resolver{client: withContext(client)}
+}
+
+// Resolve returns the entity ref points to, e.g. an *entities.Spell for a
+// reference of Type "spells". Types without a lookup, such as "traits",
+// return an error matching ErrUnsupportedReference.
+func (r *Resolver) Resolve(ctx context.Context, ref *entities.ReferenceItem) (interface{}, error) {
+	if ref == nil {
+		return nil, errors.New("ref is required")
+	}
+
+	switch ref.Type {
+	case "races", "species":
+		return r.client.GetRaceContext(ctx, ref.Key)
+	case "equipment":
+		return r.client.GetEquipmentContext(ctx, ref.Key)
+	case "classes":
+		return r.client.GetClassContext(ctx, ref.Key)
+	case "spells":
+		return r.client.GetSpellContext(ctx, ref.Key)
+	case "features":
+		return r.client.GetFeatureContext(ctx, ref.Key)
+	case "skills":
+		return r.client.GetSkillContext(ctx, ref.Key)
+	case "monsters":
+		return r.client.GetMonsterContext(ctx, ref.Key)
+	case "proficiencies":
+		return r.client.GetProficiencyContext(ctx, ref.Key)
+	case "damage-types":
+		return r.client.GetDamageTypeContext(ctx, ref.Key)
+	case "equipment-categories":
+		return r.client.GetEquipmentCategoryContext(ctx, ref.Key)
+	case "backgrounds":
+		return r.client.GetBackgroundContext(ctx, ref.Key)
+	default:
+		return nil, fmt.Errorf("resolving %s %q: %w", ref.Type, ref.Key, ErrUnsupportedReference)
+	}
+}
+
+// ResolveAs resolves ref and asserts that the entity has type T
+func ResolveAs[T any](ctx context.Context, r *Resolver, ref *entities.ReferenceItem) (T, error) {
+	var zero T
+
+	v, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return zero, err
+	}
+
+	out, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("resolving %s %q: got %T, want %T", ref.Type, ref.Key, v, zero)
+	}
+
+	return out, nil
+}
+
+// supportsReference reports whether Resolve has a lookup for ref
+func supportsReference(ref *entities.ReferenceItem) bool {
+	switch ref.Type {
+	case "races", "species", "equipment", "classes", "spells", "features", "skills",
+		"monsters", "proficiencies", "damage-types", "equipment-categories", "backgrounds":
+		return ref.Key != ""
+	default:
+		return false
+	}
+}
+
+// HydrateOptions configures Resolver.Hydrate
+type HydrateOptions struct {
+	// Depth is how many levels of references are followed: 1 loads the
+	// references in the entity itself, 2 also loads the references in those
+	// entities, and so on. Defaults to 1.
+	Depth int
+	// Concurrency bounds the number of lookups in flight. Defaults to 8.
+	Concurrency int
+}
+
+// ResolvedReferences holds the entities loaded by Resolver.Hydrate, keyed by
+// ReferenceKey
+type ResolvedReferences map[string]interface{}
+
+// Get returns the entity loaded for ref
+func (r ResolvedReferences) Get(ref *entities.ReferenceItem) (interface{}, bool) {
+	if ref == nil {
+		return nil, false
+	}
+
+	v, ok := r[ReferenceKey(ref)]
+	return v, ok
+}
+
+// ReferenceKey identifies the entity a reference points to, e.g. "spells/fireball"
+func ReferenceKey(ref *entities.ReferenceItem) string {
+	return ref.Type + "/" + ref.Key
+}
+
+// Hydrate loads every reference found in entity, at any nesting, and the
+// references of the loaded entities down to opts.Depth. Each entity is
+// loaded once. References Resolve has no lookup for are skipped. Failed
+// lookups don't stop the others; they are returned as a *BatchError keyed by
+// ReferenceKey alongside everything that did load.
+func (r *Resolver) Hydrate(ctx context.Context, entity interface{}, opts *HydrateOptions) (ResolvedReferences, error) {
+	if opts == nil {
+		opts = &HydrateOptions{}
+	}
+
+	depth := opts.Depth
+	if depth <= 0 {
+		depth = 1
+	}
+
+	resolved := make(ResolvedReferences)
+	seen := make(map[string]bool)
+	var failed BatchError
+
+	pending := []interface{}{entity}
+	for level := 0; level < depth && len(pending) > 0; level++ {
+		var keys []string
+		refs := make(map[string]*entities.ReferenceItem)
+
+		for _, v := range pending {
+			for _, ref := range collectReferences(v) {
+				key := ReferenceKey(ref)
+				if seen[key] || !supportsReference(ref) {
+					continue
+				}
+
+				seen[key] = true
+				keys = append(keys, key)
+				refs[key] = ref
+			}
+		}
+
+		results, err := Batch(ctx, keys, &BatchOptions{Concurrency: opts.Concurrency}, func(ctx context.Context, key string) (interface{}, error) {
+			return r.Resolve(ctx, refs[key])
+		})
+
+		var batchErr *BatchError
+		if err != nil && !errors.As(err, &batchErr) {
+			return resolved, err
+		}
+
+		pending = pending[:0]
+		for i, key := range keys {
+			if batchErr != nil && batchErr.Errors[i] != nil {
+				failed.Keys = append(failed.Keys, key)
+				failed.Errors = append(failed.Errors, batchErr.Errors[i])
+				continue
+			}
+
+			resolved[key] = results[i]
+			pending = append(pending, results[i])
+		}
+	}
+
+	if len(failed.Keys) > 0 {
+		return resolved, &failed
+	}
+
+	return resolved, nil
+}
+
+// collectReferences returns every non-nil *entities.ReferenceItem reachable
+// from v through pointers, structs, slices, maps and interfaces
+func collectReferences(v interface{}) []*entities.ReferenceItem {
+	var refs []*entities.ReferenceItem
+	visited := make(map[uintptr]bool)
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return
+			}
+
+			if v.Type() == referenceItemType {
+				refs = append(refs, v.Interface().(*entities.ReferenceItem))
+				return
+			}
+
+			if visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+
+			walk(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				walk(iter.Value())
+			}
+		}
+	}
+
+	walk(reflect.ValueOf(v))
+	return refs
+}
@@ -0,0 +1,115 @@
+package dnd5e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetSpell", "fireball").Return(&entities.Spell{Key: "fireball"}, nil)
+	mockClient.On("GetRace", "dwarf").Return(&entities.Race{Key: "dwarf"}, nil)
+
+	resolver := NewResolver(mockClient)
+
+	t.Run("looks up the reference by type", func(t *testing.T) {
+		v, err := resolver.Resolve(context.Background(), &entities.ReferenceItem{Key: "fireball", Type: "spells"})
+		assert.NoError(t, err)
+		assert.Equal(t, &entities.Spell{Key: "fireball"}, v)
+	})
+
+	t.Run("resolves 2024 species as races", func(t *testing.T) {
+		race, err := ResolveAs[*entities.Race](context.Background(), resolver, &entities.ReferenceItem{Key: "dwarf", Type: "species"})
+		assert.NoError(t, err)
+		assert.Equal(t, "dwarf", race.Key)
+	})
+
+	t.Run("checks the type with ResolveAs", func(t *testing.T) {
+		_, err := ResolveAs[*entities.Monster](context.Background(), resolver, &entities.ReferenceItem{Key: "fireball", Type: "spells"})
+		assert.EqualError(t, err, `resolving spells "fireball": got *entities.Spell, want *entities.Monster`)
+	})
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), &entities.ReferenceItem{Key: "darkvision", Type: "traits"})
+		assert.ErrorIs(t, err, ErrUnsupportedReference)
+	})
+}
+
+func TestResolver_Hydrate(t *testing.T) {
+	wizard := &entities.Class{
+		Key:           "wizard",
+		Proficiencies: []*entities.ReferenceItem{{Key: "daggers", Type: "proficiencies"}},
+		SavingThrows:  []*entities.ReferenceItem{{Key: "int", Type: "ability-scores"}},
+	}
+	fire := &entities.DamageType{Key: "fire"}
+	daggers := &entities.Proficiency{Key: "daggers"}
+
+	spell := &entities.Spell{
+		Key: "fireball",
+		SpellClasses: []*entities.ReferenceItem{
+			{Key: "wizard", Type: "classes"},
+			{Key: "sorcerer", Type: "classes"},
+		},
+		SpellDamage: &entities.SpellDamage{
+			SpellDamageType: &entities.ReferenceItem{Key: "fire", Type: "damage-types"},
+		},
+	}
+
+	newMock := func() *MockClient {
+		mockClient := &MockClient{}
+		mockClient.On("GetClass", "wizard").Return(wizard, nil).Once()
+		mockClient.On("GetClass", "sorcerer").Return(nil, &StatusError{StatusCode: 404}).Once()
+		mockClient.On("GetDamageType", "fire").Return(fire, nil).Once()
+		return mockClient
+	}
+
+	t.Run("loads the entity's references", func(t *testing.T) {
+		mockClient := newMock()
+
+		resolved, err := NewResolver(mockClient).Hydrate(context.Background(), spell, nil)
+
+		var batchErr *BatchError
+		if assert.ErrorAs(t, err, &batchErr) {
+			assert.Equal(t, []string{"classes/sorcerer"}, batchErr.Keys)
+			assert.ErrorIs(t, batchErr.Errors[0], ErrNotFound)
+		}
+
+		assert.Len(t, resolved, 2)
+		class, ok := resolved.Get(spell.SpellClasses[0])
+		assert.True(t, ok)
+		assert.Same(t, wizard, class)
+		assert.Equal(t, fire, resolved["damage-types/fire"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("follows references down to Depth", func(t *testing.T) {
+		mockClient := newMock()
+		mockClient.On("GetProficiency", "daggers").Return(daggers, nil).Once()
+
+		resolved, err := NewResolver(mockClient).Hydrate(context.Background(), spell, &HydrateOptions{Depth: 2, Concurrency: 1})
+		assert.Error(t, err)
+
+		assert.Len(t, resolved, 3)
+		assert.Equal(t, daggers, resolved["proficiencies/daggers"])
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCollectReferences(t *testing.T) {
+	level := &entities.Level{
+		Features: []*entities.ReferenceItem{{Key: "a"}, nil, {Key: "b"}},
+		Class:    &entities.ReferenceItem{Key: "c"},
+	}
+
+	refs := collectReferences(level)
+
+	keys := make([]string, len(refs))
+	for i, ref := range refs {
+		keys[i] = ref.Key
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Empty(t, collectReferences(nil))
+}
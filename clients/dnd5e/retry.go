@@ -0,0 +1,173 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2
+	defaultRetryJitter         = 0.2
+)
+
+// RetryPolicy controls how dnd5eAPI retries requests that fail with a transient
+// error. A nil policy disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including waits requested by a
+	// Retry-After header. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after every attempt. Values below 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction of it, e.g. 0.2 for ±20%.
+	Jitter float64
+	// RetryableStatuses lists the response status codes that are retried.
+	RetryableStatuses []int
+	// RetryableError decides whether a transport error is retried. When nil every
+	// error except context cancellation is retried.
+	RetryableError func(err error) bool
+	// IgnoreRetryAfter disables honoring the Retry-After header of 429 and 503 responses.
+	IgnoreRetryAfter bool
+}
+
+// DefaultRetryPolicy returns a policy that makes up to 3 attempts with exponential
+// backoff starting at 200ms, retrying transport errors, 429 and 5xx gateway errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+		Multiplier:     defaultRetryMultiplier,
+		Jitter:         defaultRetryJitter,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// shouldRetry reports whether a request that produced resp or err on the given
+// attempt (starting at 1) should be tried again.
+func (p *RetryPolicy) shouldRetry(attempt int, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if err != nil {
+		if p.RetryableError != nil {
+			return p.RetryableError(err)
+		}
+
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	for _, status := range p.RetryableStatuses {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns how long to wait after the given attempt (starting at 1)
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if !p.IgnoreRetryAfter && resp != nil &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.capBackoff(wait)
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	// without MaxBackoff the wait is only bounded by what a Duration can hold;
+	// float64(math.MaxInt64) rounds up, so reaching it would overflow
+	limit := float64(math.MaxInt64)
+	if p.MaxBackoff > 0 {
+		limit = float64(p.MaxBackoff)
+	}
+
+	wait := float64(p.InitialBackoff)
+	for i := 1; i < attempt && wait < limit; i++ {
+		wait *= multiplier
+	}
+
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	if wait >= float64(math.MaxInt64) {
+		return p.capBackoff(math.MaxInt64)
+	}
+
+	return p.capBackoff(time.Duration(wait))
+}
+
+func (p *RetryPolicy) capBackoff(wait time.Duration) time.Duration {
+	if wait < 0 {
+		return 0
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return wait
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+	policy.Jitter = 0
+
+	return policy
+}
+
+// newFlakyServer fails the first failures requests with status before serving body
+func newFlakyServer(failures int32, status int, header http.Header, body string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Write([]byte(body))
+	}))
+
+	return server, &calls
+}
+
+func TestDND5eAPI_Retry(t *testing.T) {
+	racesBody := `{"count": 1, "results": [{"index": "dwarf", "name": "Dwarf", "url": "/api/races/dwarf"}]}`
+
+	t.Run("retries 5xx responses until success", func(t *testing.T) {
+		server, calls := newFlakyServer(2, http.StatusServiceUnavailable, nil, racesBody)
+		defer server.Close()
+
+		client, err := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   testRetryPolicy(3),
+		})
+		assert.NoError(t, err)
+
+		races, err := client.ListRaces()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(races))
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("gives up after MaxAttempts and returns the last status", func(t *testing.T) {
+		server, calls := newFlakyServer(10, http.StatusTooManyRequests, nil, racesBody)
+		defer server.Close()
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   testRetryPolicy(2),
+		})
+
+		_, err := client.GetMonster("goblin")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("does not retry statuses that are not retryable", func(t *testing.T) {
+		server, calls := newFlakyServer(10, http.StatusNotFound, nil, racesBody)
+		defer server.Close()
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   testRetryPolicy(3),
+		})
+
+		_, err := client.GetMonster("gobln")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("does not retry without a policy", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusServiceUnavailable, nil, racesBody)
+		defer server.Close()
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+		})
+
+		_, err := client.ListRaces()
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("retries transport errors", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"races").Return(nil, errors.New("connection reset")).Twice()
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL, retry: testRetryPolicy(2)}
+		_, err := dnd5eAPI.ListRaces()
+
+		assert.EqualError(t, err, "connection reset")
+		client.AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("uses RetryableError to decide on transport errors", func(t *testing.T) {
+		client := &mockHTTPClient{}
+		client.On("Get", baserulzURL+"races").Return(nil, errors.New("permanent")).Once()
+
+		policy := testRetryPolicy(3)
+		policy.RetryableError = func(err error) bool { return false }
+
+		dnd5eAPI := &dnd5eAPI{client: client, baseURL: baserulzURL, retry: policy}
+		_, err := dnd5eAPI.ListRaces()
+
+		assert.EqualError(t, err, "permanent")
+		client.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}, racesBody)
+		defer server.Close()
+
+		policy := testRetryPolicy(2)
+		policy.MaxBackoff = 0
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   policy,
+		})
+
+		start := time.Now()
+		_, err := client.ListRaces()
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("stops waiting when ctx is cancelled", func(t *testing.T) {
+		server, calls := newFlakyServer(10, http.StatusServiceUnavailable, nil, racesBody)
+		defer server.Close()
+
+		policy := testRetryPolicy(5)
+		policy.InitialBackoff = time.Minute
+		policy.MaxBackoff = time.Minute
+
+		client, _ := NewDND5eAPI(&DND5eAPIConfig{
+			Client:  server.Client(),
+			BaseURL: server.URL + "/api/",
+			Retry:   policy,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.(ContextInterface).ListRacesContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3, nil))
+	assert.Equal(t, time.Second, policy.backoff(10, nil))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(1, nil)
+		assert.GreaterOrEqual(t, wait, 50*time.Millisecond)
+		assert.LessOrEqual(t, wait, 150*time.Millisecond)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}}
+	assert.Equal(t, time.Second, policy.backoff(1, resp))
+
+	policy.Jitter = 0
+	resp.StatusCode = http.StatusInternalServerError
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, resp), "Retry-After only applies to 429 and 503")
+
+	t.Run("doesn't overflow without MaxBackoff", func(t *testing.T) {
+		policy := &RetryPolicy{
+			InitialBackoff: 100 * time.Millisecond,
+			Multiplier:     10,
+		}
+
+		for _, attempt := range []int{20, 100, 1000} {
+			assert.Equal(t, time.Duration(math.MaxInt64), policy.backoff(attempt, nil), "attempt %d", attempt)
+		}
+
+		policy.Jitter = 0.5
+		assert.Greater(t, policy.backoff(1000, nil), time.Duration(math.MaxInt64/4))
+	})
+}
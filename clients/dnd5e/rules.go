@@ -0,0 +1,58 @@
+package dnd5e
+
+// RulesVersion selects which edition of the SRD the API serves
+type RulesVersion string
+
+const (
+	// Rules2014 targets /api/2014/, the 5th edition SRD 5.1
+	Rules2014 RulesVersion = "2014"
+	// Rules2024 targets /api/2024/, the revised SRD 5.2. Races are served as
+	// species, backgrounds grant ability scores and a feat, and weapons have a
+	// mastery property. Endpoints the API hasn't published for 2024 return
+	// ErrNotFound.
+	Rules2024 RulesVersion = "2024"
+)
+
+func (v RulesVersion) valid() bool {
+	return v == "" || v == Rules2014 || v == Rules2024
+}
+
+// rulesVersioned is implemented by clients that know which rules version they
+// serve, so a CachedClient wrapping them can namespace its cache keys.
+type rulesVersioned interface {
+	rulesVersion() RulesVersion
+}
+
+func (c *dnd5eAPI) rulesVersion() RulesVersion {
+	return c.rules
+}
+
+func (c *CachedClient) rulesVersion() RulesVersion {
+	if v, ok := c.client.(rulesVersioned); ok {
+		return v.rulesVersion()
+	}
+
+	return ""
+}
+
+// cacheNamespace returns the prefix for cache keys of entries fetched through
+// client, e.g. "2024:", so clients on different rules versions can share a
+// store. Clients without a version use unprefixed keys.
+func cacheNamespace(client Interface) string {
+	v, ok := client.(rulesVersioned)
+	if !ok || v.rulesVersion() == "" {
+		return ""
+	}
+
+	return string(v.rulesVersion()) + ":"
+}
+
+// racesPath returns the path races are served under, which the 2024 rules
+// call species.
+func (c *dnd5eAPI) racesPath() string {
+	if c.rules == Rules2024 {
+		return "species"
+	}
+
+	return "races"
+}
@@ -0,0 +1,169 @@
+package dnd5e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// newJSONServer serves the given bodies by path, 404 for anything else
+func newJSONServer(t *testing.T, bodies map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func new2024Client(t *testing.T, server *httptest.Server) Interface {
+	client, err := NewDND5eAPI(&DND5eAPIConfig{
+		Client:       server.Client(),
+		BaseURL:      server.URL + "/api/",
+		RulesVersion: Rules2024,
+	})
+	assert.NoError(t, err)
+
+	return client
+}
+
+func TestNewDND5eAPI_RulesVersion(t *testing.T) {
+	t.Run("appends the version to the base URL", func(t *testing.T) {
+		client, err := NewDND5eAPI(&DND5eAPIConfig{Client: &mockHTTPClient{}, RulesVersion: Rules2014})
+		assert.NoError(t, err)
+		assert.Equal(t, baserulzURL+"2014/", client.(*dnd5eAPI).getBaseURL())
+
+		client, err = NewDND5eAPI(&DND5eAPIConfig{Client: &mockHTTPClient{}, BaseURL: "http://localhost/api", RulesVersion: Rules2024})
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost/api/2024/", client.(*dnd5eAPI).getBaseURL())
+	})
+
+	t.Run("keeps the unversioned routes by default", func(t *testing.T) {
+		client, err := NewDND5eAPI(&DND5eAPIConfig{Client: &mockHTTPClient{}})
+		assert.NoError(t, err)
+		assert.Equal(t, baserulzURL, client.(*dnd5eAPI).getBaseURL())
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		_, err := NewDND5eAPI(&DND5eAPIConfig{Client: &mockHTTPClient{}, RulesVersion: "2000"})
+		assert.EqualError(t, err, `cfg.RulesVersion "2000" is not supported`)
+	})
+}
+
+func TestDND5eAPI_Rules2024(t *testing.T) {
+	server := newJSONServer(t, map[string]string{
+		"/api/2024/species": `{"count": 1, "results": [
+			{"index": "dwarf", "name": "Dwarf", "url": "/api/2024/species/dwarf"}]}`,
+		"/api/2024/species/dwarf": `{"index": "dwarf", "name": "Dwarf", "type": "Humanoid", "size": "Medium", "speed": 30,
+			"traits": [{"index": "darkvision", "name": "Darkvision", "url": "/api/2024/traits/darkvision"}],
+			"subspecies": [], "url": "/api/2024/species/dwarf"}`,
+		"/api/2024/equipment/club": `{"index": "club", "name": "Club",
+			"equipment_categories": [
+				{"index": "weapons", "name": "Weapons", "url": "/api/2024/equipment-categories/weapons"},
+				{"index": "simple-weapons", "name": "Simple Weapons", "url": "/api/2024/equipment-categories/simple-weapons"}],
+			"weapon_category": "Simple", "weapon_range": "Melee",
+			"damage": {"damage_dice": "1d4", "damage_type": {"index": "bludgeoning", "name": "Bludgeoning", "url": "/api/2024/damage-types/bludgeoning"}},
+			"mastery": {"index": "slow", "name": "Slow", "url": "/api/2024/weapon-mastery-properties/slow"},
+			"url": "/api/2024/equipment/club"}`,
+		"/api/2024/backgrounds": `{"count": 1, "results": [
+			{"index": "acolyte", "name": "Acolyte", "url": "/api/2024/backgrounds/acolyte"}]}`,
+		"/api/2024/backgrounds/acolyte": `{"index": "acolyte", "name": "Acolyte",
+			"ability_scores": [
+				{"index": "int", "name": "INT", "url": "/api/2024/ability-scores/int"},
+				{"index": "wis", "name": "WIS", "url": "/api/2024/ability-scores/wis"},
+				{"index": "cha", "name": "CHA", "url": "/api/2024/ability-scores/cha"}],
+			"feat": {"index": "magic-initiate", "name": "Magic Initiate", "url": "/api/2024/feats/magic-initiate"},
+			"proficiencies": [{"index": "skill-insight", "name": "Skill: Insight", "url": "/api/2024/proficiencies/skill-insight"}],
+			"url": "/api/2024/backgrounds/acolyte"}`,
+	})
+	client := new2024Client(t, server)
+
+	t.Run("lists races from species", func(t *testing.T) {
+		races, err := client.ListRaces()
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.ReferenceItem{{Key: "dwarf", Name: "Dwarf", Type: "species"}}, races)
+	})
+
+	t.Run("maps species to races", func(t *testing.T) {
+		race, err := client.GetRace("dwarf")
+		assert.NoError(t, err)
+		assert.Equal(t, "Humanoid", race.CreatureType)
+		assert.Equal(t, 30, race.Speed)
+		assert.Equal(t, []*entities.ReferenceItem{{Key: "darkvision", Name: "Darkvision", Type: "traits"}}, race.Traits)
+	})
+
+	t.Run("maps weapon mastery", func(t *testing.T) {
+		equipment, err := client.GetEquipment("club")
+		assert.NoError(t, err)
+
+		weapon, ok := equipment.(*entities.Weapon)
+		if assert.True(t, ok) {
+			assert.Equal(t, &entities.ReferenceItem{Key: "slow", Name: "Slow", Type: "weapon-mastery-properties"}, weapon.Mastery)
+			assert.Equal(t, "weapons", weapon.EquipmentCategory.Key)
+		}
+	})
+
+	t.Run("maps background ability scores and feat", func(t *testing.T) {
+		background, err := client.GetBackground("acolyte")
+		assert.NoError(t, err)
+		assert.Len(t, background.AbilityScores, 3)
+		assert.Equal(t, "magic-initiate", background.Feat.Key)
+		assert.Equal(t, "skill-insight", background.SkillProficiencies[0].Key)
+	})
+
+	t.Run("lists only backgrounds that can be fetched", func(t *testing.T) {
+		backgrounds, err := client.ListBackgrounds()
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.ReferenceItem{{Key: "acolyte", Name: "Acolyte", Type: "backgrounds"}}, backgrounds)
+
+		for _, ref := range backgrounds {
+			_, err := client.GetBackground(ref.Key)
+			assert.NoError(t, err, ref.Key)
+		}
+	})
+
+	t.Run("returns list errors instead of 2014 backgrounds", func(t *testing.T) {
+		empty := new2024Client(t, newJSONServer(t, nil))
+
+		_, err := empty.ListBackgrounds()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("does not fall back to 2014 backgrounds", func(t *testing.T) {
+		_, err := client.GetBackground("sage")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestCachedClient_RulesVersionNamespace(t *testing.T) {
+	server := newJSONServer(t, map[string]string{
+		"/api/2024/species/dwarf": `{"index": "dwarf", "name": "Dwarf"}`,
+	})
+
+	var keys []string
+	cachedClient, err := NewCachedClientWithConfig(&CachedClientConfig{
+		Client: new2024Client(t, server),
+		TTL:    time.Hour,
+		OnEvent: func(event CacheEvent) {
+			keys = append(keys, event.Key)
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = cachedClient.GetRace("dwarf")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024:race:dwarf"}, keys)
+
+	assert.True(t, cachedClient.Invalidate("race:dwarf"))
+	assert.Equal(t, 0, cachedClient.Stats()[ResourceRace].Entries)
+}
@@ -51,7 +51,7 @@ func equipmentResultToEquipment(input *equipmentResult) *entities.Equipment {
 		Cost:   costResultToCost(input.Cost),
 		Weight: input.Weight,
 		EquipmentCategory: referenceItemToReferenceItem(
-			input.EquipmentCategory,
+			primaryCategory(input.EquipmentCategory, input.EquipmentCategories),
 		),
 	}
 }
@@ -88,13 +88,14 @@ func weaponResultToWeapon(input *weaponResult) *entities.Weapon {
 		Cost:              costResultToCost(input.Cost),
 		Damage:            damageResultToDamage(input.Damage),
 		Weight:            input.Weight,
-		EquipmentCategory: referenceItemToReferenceItem(input.EquipmentCategory),
+		EquipmentCategory: referenceItemToReferenceItem(primaryCategory(input.EquipmentCategory, input.EquipmentCategories)),
 		WeaponCategory:    input.WeaponCategory,
 		WeaponRange:       input.WeaponRange,
 		CategoryRange:     input.CategoryRange,
 		Range:             weaponRangeResultToWeaponRange(input.Range),
 		Properties:        referenceItemsToReferenceItems(input.Properties),
 		TwoHandedDamage:   damageResultToDamage(input.TwoHandedDamage),
+		Mastery:           referenceItemToReferenceItem(input.Mastery),
 	}
 }
 
@@ -112,7 +113,7 @@ func armorResultToArmor(input *armorResult) *entities.Armor {
 		StrMinimum:          input.StrMinimum,
 		StealthDisadvantage: input.StealthDisadvantage,
 		Weight:              input.Weight,
-		EquipmentCategory:   referenceItemToReferenceItem(input.EquipmentCategory),
+		EquipmentCategory:   referenceItemToReferenceItem(primaryCategory(input.EquipmentCategory, input.EquipmentCategories)),
 	}
 }
 
@@ -611,3 +612,90 @@ func backgroundFeatureResultToBackgroundFeature(input *backgroundFeatureResult)
 		Description: description,
 	}
 }
+
+func classResultToClass(response *classResult, startingEquipmentOptions []*entities.ChoiceOption) *entities.Class {
+	armorProfs, weaponProfs, toolProfs := categorizeProficiencies(response.Proficiencies)
+
+	class := &entities.Class{
+		Key:                      response.Index,
+		Name:                     response.Name,
+		HitDie:                   response.HitDie,
+		Proficiencies:            referenceItemsToReferenceItems(response.Proficiencies),
+		SavingThrows:             referenceItemsToReferenceItems(response.SavingThrows),
+		StartingEquipment:        startingEquipmentResultsToStartingEquipment(response.StartingEquipment),
+		ProficiencyChoices:       choiceResultsToChoices(response.ProficiencyChoices),
+		StartingEquipmentOptions: startingEquipmentOptions,
+		PrimaryAbilities:         extractPrimaryAbilities(response.MultiClassing),
+		Description:              getClassDescription(response.Index),
+		ArmorProficiencies:       armorProfs,
+		WeaponProficiencies:      weaponProfs,
+		ToolProficiencies:        toolProfs,
+	}
+
+	return class
+}
+
+func featureResultToFeature(response *featureResult) *entities.Feature {
+	feature := &entities.Feature{
+		Key:   response.Index,
+		Name:  response.Name,
+		Level: response.Level, //TODO: add prerequisites?
+		Class: referenceItemToReferenceItem(response.Class),
+	}
+
+	if response.FeatureSpecific != nil {
+		feature.FeatureSpecific = &entities.SubFeatureOption{
+			SubFeatureOptions: choiceResultToChoice(response.FeatureSpecific.SubFeatureOptions),
+		}
+	}
+
+	return feature
+}
+
+func monsterResultToMonster(response *monsterResult) *entities.Monster {
+	monster := &entities.Monster{
+		Key:                   response.Index,
+		Name:                  response.Name,
+		Size:                  response.Size,
+		Type:                  response.Type,
+		Alignment:             response.Alignment,
+		ArmorClass:            monsterArmorClassToValue(response.ArmorClass),
+		HitPoints:             response.HitPoints,
+		HitDice:               response.HitDice,
+		Speed:                 monsterSpeedResultToSpeed(response.Speed),
+		Strength:              response.Strength,
+		Dexterity:             response.Dexterity,
+		Constitution:          response.Constitution,
+		Intelligence:          response.Intelligence,
+		Wisdom:                response.Wisdom,
+		Charisma:              response.Charisma,
+		Proficiencies:         monsterProficiencyResultsToMonsterProficiencies(response.Proficiencies),
+		DamageVulnerabilities: response.DamageVulnerabilities,
+		DamageResistances:     response.DamageResistances,
+		DamageImmunities:      response.DamageImmunities,
+		ConditionImmunities:   referenceItemsToReferenceItems(response.ConditionImmunities),
+		MonsterSenses:         monsterSensesResultToMonsterSenses(response.Senses),
+		Languages:             response.Languages,
+		ChallengeRating:       response.ChallengeRating,
+		XP:                    response.XP,
+		MonsterActions:        monsterActionResultsToMonsterActions(response.MonsterActions),
+		MonsterImageURL:       response.MonsterImageURL,
+	}
+
+	return monster
+}
+
+func levelResultToLevel(response *levelResult) *entities.Level {
+	classLevel := &entities.Level{
+		Level:               response.Level,
+		AbilityScoreBonuses: response.AbilityScoreBonuses,
+		ProfBonus:           response.ProfBonus,
+		Features:            referenceItemsToReferenceItems(response.Features),
+		SpellCasting:        spellCastingResultToSpellCasting(response.SpellCasting),
+		ClassSpecific:       levelResultToClassSpecific(response),
+		Key:                 response.Index,
+		Class:               referenceItemToReferenceItem(response.Class),
+	}
+
+	return classLevel
+}
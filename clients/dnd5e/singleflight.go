@@ -0,0 +1,81 @@
+package dnd5e
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent calls for the same key: while a call is in
+// flight, later callers wait for it and share its result instead of starting
+// their own. The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// do runs fn once for all concurrent callers of key and waits for its result
+// or for ctx to be done, whichever comes first. fn runs on its own goroutine
+// and keeps going when callers give up, so it must not depend on any one
+// caller's context. shared reports whether another caller started the call.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (val interface{}, err error, shared bool) {
+	g.mu.Lock()
+	call, shared := g.calls[key]
+	if !shared {
+		call = g.start(key, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err, shared
+	case <-ctx.Done():
+		return nil, ctx.Err(), shared
+	}
+}
+
+// doAsync starts fn in a new goroutine unless a call for key is already in
+// flight, and reports whether it was started. Callers of do for the same key
+// wait for it as for any other call.
+func (g *flightGroup) doAsync(key string, fn func() (interface{}, error)) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.calls[key]; ok {
+		return false
+	}
+
+	g.start(key, fn)
+	return true
+}
+
+// start registers a call for key and runs fn for it in a new goroutine. g.mu
+// must be held.
+func (g *flightGroup) start(key string, fn func() (interface{}, error)) *flightCall {
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+
+	go func() {
+		defer g.finish(key, call)
+		call.val, call.err = fn()
+	}()
+
+	return call
+}
+
+// finish removes a completed call and releases its waiters
+func (g *flightGroup) finish(key string, call *flightCall) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,228 @@
+package dnd5e
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightGroup_Do(t *testing.T) {
+	t.Run("concurrent callers share one call", func(t *testing.T) {
+		var group flightGroup
+		var calls int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		results := make([]interface{}, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _, _ = group.do(context.Background(), "monster:goblin", func() (interface{}, error) {
+					atomic.AddInt32(&calls, 1)
+					<-release
+					return "goblin", nil
+				})
+			}(i)
+		}
+
+		// give every goroutine a chance to join the flight
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, result := range results {
+			assert.Equal(t, "goblin", result)
+		}
+	})
+
+	t.Run("shares errors", func(t *testing.T) {
+		var group flightGroup
+		apiErr := errors.New("API error")
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		var shared int32
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err, isShared := group.do(context.Background(), "monster:goblin", func() (interface{}, error) {
+					<-release
+					return nil, apiErr
+				})
+				assert.Equal(t, apiErr, err)
+				if isShared {
+					atomic.AddInt32(&shared, 1)
+				}
+			}()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(4), atomic.LoadInt32(&shared))
+	})
+
+	t.Run("sequential calls are not shared", func(t *testing.T) {
+		var group flightGroup
+		var calls int32
+
+		for i := 0; i < 3; i++ {
+			group.do(context.Background(), "monster:goblin", func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				return nil, errors.New("API error")
+			})
+		}
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestFlightGroup_DoContext(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		<-release
+		return "goblin", nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err, _ := group.do(ctx, "monster:goblin", fn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// the call keeps running for callers that are still waiting
+	done := make(chan interface{})
+	go func() {
+		val, _, shared := group.do(context.Background(), "monster:goblin", fn)
+		assert.True(t, shared)
+		done <- val
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.Equal(t, "goblin", <-done)
+}
+
+// slowClient counts GetMonster calls and blocks them until release is closed
+type slowClient struct {
+	MockClient
+	calls   int32
+	release chan struct{}
+}
+
+func (s *slowClient) GetMonsterContext(ctx context.Context, key string) (*entities.Monster, error) {
+	atomic.AddInt32(&s.calls, 1)
+	<-s.release
+	return &entities.Monster{Key: key}, nil
+}
+
+func TestFlightGroup_DoAsync(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+
+	started := group.doAsync("monster:goblin", func() (interface{}, error) {
+		<-release
+		return "goblin", nil
+	})
+	assert.True(t, started)
+
+	// a second refresh is skipped while the first is in flight
+	assert.False(t, group.doAsync("monster:goblin", func() (interface{}, error) {
+		return "hobgoblin", nil
+	}))
+
+	done := make(chan interface{})
+	go func() {
+		val, _, shared := group.do(context.Background(), "monster:goblin", func() (interface{}, error) {
+			return "hobgoblin", nil
+		})
+		assert.True(t, shared)
+		done <- val
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.Equal(t, "goblin", <-done)
+}
+
+func TestCachedClient_CoalescesConcurrentMisses(t *testing.T) {
+	client := &slowClient{release: make(chan struct{})}
+	cachedClient := NewCachedClient(client, 24*time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			monster, err := cachedClient.GetMonster("adult-blue-dragon")
+			assert.NoError(t, err)
+			assert.Equal(t, "adult-blue-dragon", monster.Key)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(client.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&client.calls))
+}
+
+func TestCachedClient_CoalescedContexts(t *testing.T) {
+	t.Run("leader cancelling doesn't fail followers", func(t *testing.T) {
+		client := &slowClient{release: make(chan struct{})}
+		cachedClient := NewCachedClient(client, 24*time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error)
+		go func() {
+			_, err := cachedClient.(ContextInterface).GetMonsterContext(ctx, "goblin")
+			leaderErr <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		followerErr := make(chan error)
+		go func() {
+			_, err := cachedClient.(ContextInterface).GetMonsterContext(context.Background(), "goblin")
+			followerErr <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+		close(client.release)
+		assert.NoError(t, <-followerErr)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&client.calls))
+	})
+
+	t.Run("followers stop waiting at their deadline", func(t *testing.T) {
+		client := &slowClient{release: make(chan struct{})}
+		defer close(client.release)
+		cachedClient := NewCachedClient(client, 24*time.Hour)
+
+		go cachedClient.GetMonster("goblin")
+		time.Sleep(10 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		monster, err := cachedClient.(ContextInterface).GetMonsterContext(ctx, "goblin")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, monster)
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+}
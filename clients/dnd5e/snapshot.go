@@ -0,0 +1,388 @@
+package dnd5e
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// SnapshotManifestFile is the name of the manifest in a snapshot directory
+	SnapshotManifestFile = "manifest.json"
+
+	defaultSnapshotConcurrency = 8
+)
+
+// snapshotLists are the list endpoints crawled by Snapshot, with the kind used
+// in errors for the list and its items
+var snapshotLists = []struct {
+	path string
+	kind ResourceKind
+}{
+	{"races", ResourceRace},
+	{"classes", ResourceClass},
+	{"spells", ResourceSpell},
+	{"monsters", ResourceMonster},
+	{"equipment", ResourceEquipment},
+	{"equipment-categories", ResourceEquipmentCategory},
+	{"features", ResourceFeature},
+	{"skills", ResourceSkill},
+	{"proficiencies", ResourceProficiency},
+	{"damage-types", ResourceDamageType},
+	{"backgrounds", ResourceBackground},
+}
+
+// SnapshotConfig configures Snapshot
+type SnapshotConfig struct {
+	// API configures the client used for the crawl, including its retry policy
+	// and rate limiter.
+	API *DND5eAPIConfig
+	// Dir is where snapshots are written, each in a subdirectory named after
+	// its version.
+	Dir string
+	// Version names the snapshot. Defaults to the current UTC date.
+	Version string
+	// Concurrency bounds the number of requests in flight. Defaults to 8.
+	Concurrency int
+	// Resume keeps files already in the snapshot directory, e.g. from an
+	// interrupted run, and only downloads what is missing or doesn't match the
+	// checksum in its manifest.
+	Resume bool
+}
+
+// SnapshotManifest describes the files of a snapshot. It is stored next to
+// them as manifest.json.
+type SnapshotManifest struct {
+	Version   string                  `json:"version"`
+	BaseURL   string                  `json:"base_url"`
+	CreatedAt time.Time               `json:"created_at"`
+	Files     map[string]SnapshotFile `json:"files"`
+}
+
+// SnapshotFile is the manifest entry of one response, keyed by its path
+// relative to the snapshot directory, e.g. "monsters/goblin.json"
+type SnapshotFile struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// SnapshotError lists the downloads that failed while taking a snapshot
+type SnapshotError struct {
+	Errors []error
+}
+
+func (e *SnapshotError) Error() string {
+	if len(e.Errors) == 1 {
+		return "taking snapshot: " + e.Errors[0].Error()
+	}
+
+	return fmt.Sprintf("taking snapshot: %d downloads failed, first: %v", len(e.Errors), e.Errors[0])
+}
+
+// Snapshot crawls every list endpoint of the API, and every item, class level
+// and class spell list they reference, and stores the raw responses under
+// cfg.Dir/cfg.Version in the layout read by NewOfflineClient. The manifest is
+// written even if some downloads fail, so the snapshot can be resumed; the
+// failures are returned as a *SnapshotError.
+func Snapshot(ctx context.Context, cfg *SnapshotConfig) (*SnapshotManifest, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is required")
+	}
+
+	if cfg.Dir == "" {
+		return nil, errors.New("cfg.Dir is required")
+	}
+
+	client, err := NewDND5eAPI(cfg.API)
+	if err != nil {
+		return nil, err
+	}
+
+	version := cfg.Version
+	if version == "" {
+		version = time.Now().UTC().Format("2006-01-02")
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultSnapshotConcurrency
+	}
+
+	s := &snapshotter{
+		api: client.(*dnd5eAPI),
+		dir: filepath.Join(cfg.Dir, version),
+		manifest: &SnapshotManifest{
+			Version:   version,
+			BaseURL:   client.(*dnd5eAPI).getBaseURL(),
+			CreatedAt: time.Now().UTC(),
+			Files:     make(map[string]SnapshotFile),
+		},
+	}
+
+	if cfg.Resume {
+		previous, err := readSnapshotManifest(s.dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		s.previous = previous
+		s.resume = true
+	}
+
+	err = os.MkdirAll(s.dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &warmer{
+		ctx: ctx,
+		sem: make(chan struct{}, concurrency),
+	}
+
+	for _, l := range snapshotLists {
+		l := l
+		if l.kind == ResourceRace {
+			l.path = s.api.racesPath()
+		}
+
+		w.run(func(ctx context.Context) error {
+			list := listResponse{}
+			err := s.fetchJSON(ctx, l.kind, "", l.path, &list)
+			if err != nil {
+				return err
+			}
+
+			for _, item := range list.Results {
+				key := item.Index
+				w.run(func(ctx context.Context) error {
+					_, err := s.fetch(ctx, l.kind, key, l.path+"/"+key)
+					return err
+				})
+
+				if l.kind == ResourceClass {
+					s.crawlClass(w, key)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	w.wg.Wait()
+
+	err = s.writeManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(w.errs) > 0 {
+		return s.manifest, &SnapshotError{Errors: w.errs}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return s.manifest, err
+	}
+
+	return s.manifest, nil
+}
+
+// VerifySnapshot checks every file listed in the manifest of the snapshot in
+// dir against its size and checksum and returns the manifest
+func VerifySnapshot(dir string) (*SnapshotManifest, error) {
+	manifest, err := readSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(manifest.Files))
+	for name := range manifest.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return manifest, err
+		}
+
+		if newSnapshotFile(data) != manifest.Files[name] {
+			return manifest, fmt.Errorf("%s: checksum mismatch", name)
+		}
+	}
+
+	return manifest, nil
+}
+
+// snapshotter downloads the files of one snapshot and tracks its manifest
+type snapshotter struct {
+	api *dnd5eAPI
+	dir string
+
+	resume   bool
+	previous *SnapshotManifest
+
+	mu       sync.Mutex
+	manifest *SnapshotManifest
+}
+
+// crawlClass queues the spell list and the levels of a class
+func (s *snapshotter) crawlClass(w *warmer, class string) {
+	w.run(func(ctx context.Context) error {
+		_, err := s.fetch(ctx, ResourceSpell, class, "classes/"+class+"/spells")
+		return err
+	})
+
+	w.run(func(ctx context.Context) error {
+		levels := []*levelResult{}
+		err := s.fetchJSON(ctx, ResourceClassLevel, class, "classes/"+class+"/levels", &levels)
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[int]bool)
+		for _, level := range levels {
+			if seen[level.Level] {
+				continue
+			}
+			seen[level.Level] = true
+
+			n := strconv.Itoa(level.Level)
+			w.run(func(ctx context.Context) error {
+				_, err := s.fetch(ctx, ResourceClassLevel, class+"/"+n, "classes/"+class+"/levels/"+n)
+				return err
+			})
+		}
+
+		return nil
+	})
+}
+
+// fetchJSON fetches name and decodes it into out
+func (s *snapshotter) fetchJSON(ctx context.Context, kind ResourceKind, key, name string, out interface{}) error {
+	data, err := s.fetch(ctx, kind, key, name)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return newDecodeError(kind, key, s.api.getBaseURL()+name, err)
+	}
+
+	return nil
+}
+
+// fetch returns the response for name, an API path relative to the base URL,
+// downloading and storing it unless a resumed snapshot already has it. Names
+// built from indexes the server returned are rejected unless canonical, so a
+// broken or hostile server can't make it write outside the snapshot directory.
+func (s *snapshotter) fetch(ctx context.Context, kind ResourceKind, key, name string) ([]byte, error) {
+	if name == "" || path.Clean(name) != name || !fs.ValidPath(name) {
+		return nil, fmt.Errorf("can't store %s %q: unsupported path %s", kind, key, name)
+	}
+
+	file := filepath.Join(s.dir, filepath.FromSlash(name)+".json")
+
+	if s.resume {
+		if data, ok := s.existing(name, file); ok {
+			s.record(name, data)
+			return data, nil
+		}
+	}
+
+	resp, err := s.api.get(ctx, kind, key, s.api.getBaseURL()+name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFileAtomic(file, data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(name, data)
+	return data, nil
+}
+
+// existing returns the stored response for name if it can be kept. Files are
+// written atomically, so any file is complete; if the previous manifest lists
+// it, its checksum must match too.
+func (s *snapshotter) existing(name, file string) ([]byte, bool) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+
+	if s.previous != nil {
+		if want, ok := s.previous.Files[name+".json"]; ok && want != newSnapshotFile(data) {
+			return nil, false
+		}
+	}
+
+	return data, true
+}
+
+func (s *snapshotter) record(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.manifest.Files[name+".json"] = newSnapshotFile(data)
+}
+
+func (s *snapshotter) writeManifest() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.manifest, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(s.dir, SnapshotManifestFile), data)
+}
+
+func newSnapshotFile(data []byte) SnapshotFile {
+	sum := sha256.Sum256(data)
+
+	return SnapshotFile{
+		Size:   int64(len(data)),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+}
+
+func readSnapshotManifest(dir string) (*SnapshotManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &SnapshotManifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot manifest: %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,198 @@
+package dnd5e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSnapshotServer serves a tiny API: one race, and a wizard class with a spell
+// and two levels. Other lists are empty. It counts the requests per path.
+func newSnapshotServer() (*httptest.Server, *sync.Map) {
+	responses := map[string]string{
+		"races":                   `{"count": 1, "results": [{"index": "dwarf", "name": "Dwarf", "url": "/api/races/dwarf"}]}`,
+		"races/dwarf":             `{"index": "dwarf", "name": "Dwarf", "speed": 25}`,
+		"classes":                 `{"count": 1, "results": [{"index": "wizard", "name": "Wizard", "url": "/api/classes/wizard"}]}`,
+		"classes/wizard":          `{"index": "wizard", "name": "Wizard", "hit_die": 6}`,
+		"classes/wizard/spells":   `{"count": 1, "results": [{"index": "fireball", "name": "Fireball", "url": "/api/spells/fireball"}]}`,
+		"classes/wizard/levels":   `[{"level": 1, "index": "wizard-1"}, {"level": 2, "index": "wizard-2"}, {"level": 2, "index": "wizard-evocation-2"}]`,
+		"classes/wizard/levels/1": `{"level": 1, "prof_bonus": 2, "index": "wizard-1", "class": {"index": "wizard", "name": "Wizard"}}`,
+		"classes/wizard/levels/2": `{"level": 2, "prof_bonus": 2, "index": "wizard-2", "class": {"index": "wizard", "name": "Wizard"}}`,
+	}
+
+	var calls sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/api/")
+		count, _ := calls.LoadOrStore(name, new(int32))
+		atomic.AddInt32(count.(*int32), 1)
+
+		if body, ok := responses[name]; ok {
+			w.Write([]byte(body))
+			return
+		}
+
+		if !strings.Contains(name, "/") {
+			w.Write([]byte(`{"count": 0, "results": []}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	return server, &calls
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Run("cfg.Dir is required", func(t *testing.T) {
+		_, err := Snapshot(context.Background(), &SnapshotConfig{API: &DND5eAPIConfig{Client: http.DefaultClient}})
+		assert.EqualError(t, err, "cfg.Dir is required")
+	})
+
+	t.Run("downloads every list and item", func(t *testing.T) {
+		server, _ := newSnapshotServer()
+		defer server.Close()
+
+		dir := t.TempDir()
+		manifest, err := Snapshot(context.Background(), &SnapshotConfig{
+			API:     &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"},
+			Dir:     dir,
+			Version: "test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "test", manifest.Version)
+		assert.Len(t, manifest.Files, 17)
+		assert.Contains(t, manifest.Files, "classes/wizard/levels/2.json")
+
+		verified, err := VerifySnapshot(filepath.Join(dir, "test"))
+		assert.NoError(t, err)
+		assert.Equal(t, manifest.Files, verified.Files)
+
+		// the snapshot can be read back offline
+		client, err := NewOfflineClient(&OfflineConfig{FS: os.DirFS(filepath.Join(dir, "test"))})
+		assert.NoError(t, err)
+
+		race, err := client.GetRace("dwarf")
+		assert.NoError(t, err)
+		assert.Equal(t, 25, race.Speed)
+
+		level, err := client.GetClassLevel("wizard", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "wizard-2", level.Key)
+	})
+
+	t.Run("resume downloads only missing and modified files", func(t *testing.T) {
+		server, _ := newSnapshotServer()
+		defer server.Close()
+
+		dir := t.TempDir()
+		cfg := &SnapshotConfig{
+			API:     &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"},
+			Dir:     dir,
+			Version: "test",
+		}
+
+		_, err := Snapshot(context.Background(), cfg)
+		assert.NoError(t, err)
+
+		os.Remove(filepath.Join(dir, "test", "races", "dwarf.json"))
+		os.WriteFile(filepath.Join(dir, "test", "classes", "wizard.json"), []byte("{}"), 0o644)
+
+		server2, calls := newSnapshotServer()
+		defer server2.Close()
+
+		cfg.API = &DND5eAPIConfig{Client: server2.Client(), BaseURL: server2.URL + "/api/"}
+		cfg.Resume = true
+		manifest, err := Snapshot(context.Background(), cfg)
+		assert.NoError(t, err)
+		assert.Len(t, manifest.Files, 17)
+
+		var fetched []string
+		calls.Range(func(key, value interface{}) bool {
+			fetched = append(fetched, key.(string))
+			return true
+		})
+		assert.ElementsMatch(t, []string{"races/dwarf", "classes/wizard"}, fetched)
+
+		_, err = VerifySnapshot(filepath.Join(dir, "test"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed downloads are reported", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/races" {
+				w.Write([]byte(`{"count": 1, "results": [{"index": "dwarf", "name": "Dwarf"}]}`))
+				return
+			}
+			if r.URL.Path == "/api/races/dwarf" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"count": 0, "results": []}`))
+		}))
+		defer server.Close()
+
+		manifest, err := Snapshot(context.Background(), &SnapshotConfig{
+			API: &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"},
+			Dir: t.TempDir(),
+		})
+
+		var snapshotErr *SnapshotError
+		assert.ErrorAs(t, err, &snapshotErr)
+		assert.Len(t, snapshotErr.Errors, 1)
+		assert.ErrorIs(t, snapshotErr.Errors[0], ErrNotFound)
+		assert.NotContains(t, manifest.Files, "races/dwarf.json")
+	})
+
+	t.Run("indexes can't escape the snapshot directory", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/races" {
+				w.Write([]byte(`{"count": 1, "results": [{"index": "../../../escaped", "name": "Escaped"}]}`))
+				return
+			}
+			w.Write([]byte(`{"count": 0, "results": []}`))
+		}))
+		defer server.Close()
+
+		dir := t.TempDir()
+		manifest, err := Snapshot(context.Background(), &SnapshotConfig{
+			API:     &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"},
+			Dir:     filepath.Join(dir, "snapshots"),
+			Version: "v1",
+		})
+
+		var snapshotErr *SnapshotError
+		if assert.ErrorAs(t, err, &snapshotErr) && assert.Len(t, snapshotErr.Errors, 1) {
+			assert.EqualError(t, snapshotErr.Errors[0], `can't store race "../../../escaped": unsupported path races/../../../escaped`)
+		}
+		assert.Len(t, manifest.Files, len(snapshotLists))
+
+		_, err = os.Stat(filepath.Join(dir, "escaped.json"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	server, _ := newSnapshotServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := Snapshot(context.Background(), &SnapshotConfig{
+		API:     &DND5eAPIConfig{Client: server.Client(), BaseURL: server.URL + "/api/"},
+		Dir:     dir,
+		Version: "test",
+	})
+	assert.NoError(t, err)
+
+	os.WriteFile(filepath.Join(dir, "test", "races.json"), []byte(`{"count": 0}`), 0o644)
+
+	_, err = VerifySnapshot(filepath.Join(dir, "test"))
+	assert.EqualError(t, err, "races.json: checksum mismatch")
+}
@@ -19,6 +19,17 @@ type raceResult struct {
 	StartingProficiencies      []*referenceItem `json:"starting_proficiencies"`
 	StartingProficiencyOptions *choiceResult    `json:"starting_proficiency_options"`
 	LanguageOptions            *choiceResult    `json:"language_options"`
+	// Type and Subspecies are only set by the 2024 species endpoint
+	Type       string           `json:"type"`
+	Subspecies []*referenceItem `json:"subspecies"`
+}
+
+func (r *raceResult) getSubRaces() []*referenceItem {
+	if r.SubRaces != nil {
+		return r.SubRaces
+	}
+
+	return r.Subspecies
 }
 
 type abilityBonus struct {
@@ -41,14 +52,39 @@ type equipmentResult struct {
 	Cost              *cost          `json:"cost"`
 	Weight            float32        `json:"weight"`
 	EquipmentCategory *referenceItem `json:"equipment_category"`
+	// EquipmentCategories replaces EquipmentCategory in the 2024 rules
+	EquipmentCategories []*referenceItem `json:"equipment_categories"`
 }
 
 func (e *equipmentResult) getCategoryKey() string {
-	if e.EquipmentCategory == nil {
-		return ""
+	if e.EquipmentCategory != nil {
+		return e.EquipmentCategory.Index
+	}
+
+	// 2024 items list every category they belong to, with plural names
+	for _, category := range e.EquipmentCategories {
+		if category == nil {
+			continue
+		}
+
+		switch category.Index {
+		case "weapon", "weapons":
+			return "weapon"
+		case "armor":
+			return "armor"
+		}
+	}
+
+	return ""
+}
+
+// primaryCategory returns the 2014 category, or the first 2024 one
+func primaryCategory(category *referenceItem, categories []*referenceItem) *referenceItem {
+	if category != nil || len(categories) == 0 {
+		return category
 	}
 
-	return e.EquipmentCategory.Index
+	return categories[0]
 }
 
 type cost struct {
@@ -69,6 +105,9 @@ type weaponResult struct {
 	Range             *weaponRange     `json:"range"`
 	Properties        []*referenceItem `json:"properties"`
 	TwoHandedDamage   *damage          `json:"two_handed_damage"`
+	// EquipmentCategories and Mastery are only set by the 2024 rules
+	EquipmentCategories []*referenceItem `json:"equipment_categories"`
+	Mastery             *referenceItem   `json:"mastery"`
 }
 
 type damage struct {
@@ -90,6 +129,8 @@ type armorResult struct {
 	ArmorClass          *armorClass    `json:"armor_class"`
 	StrMinimum          int            `json:"str_minimum"`
 	StealthDisadvantage bool           `json:"stealth_disadvantage"`
+	// EquipmentCategories replaces EquipmentCategory in the 2024 rules
+	EquipmentCategories []*referenceItem `json:"equipment_categories"`
 }
 
 type armorClass struct {
@@ -356,6 +397,13 @@ type backgroundResult struct {
 	Ideals                     *choiceResult    `json:"ideals"`
 	Bonds                      *choiceResult    `json:"bonds"`
 	Flaws                      *choiceResult    `json:"flaws"`
+	// The 2024 rules move ability score increases and an origin feat to the
+	// background and rename the proficiency and equipment fields
+	AbilityScores      []*referenceItem `json:"ability_scores"`
+	Feat               *referenceItem   `json:"feat"`
+	Proficiencies      []*referenceItem `json:"proficiencies"`
+	ProficiencyChoices []*choiceResult  `json:"proficiency_choices"`
+	EquipmentOptions   []*choiceResult  `json:"equipment_options"`
 }
 
 type backgroundFeatureResult struct {
@@ -12,9 +12,42 @@ type Background struct {
 	Ideals             *ChoiceOption            `json:"ideals"`
 	Bonds              *ChoiceOption            `json:"bonds"`
 	Flaws              *ChoiceOption            `json:"flaws"`
+	// AbilityScores, Feat and ProficiencyChoices are set by the 2024 rules:
+	// the ability scores the background can increase, its origin feat, and
+	// any proficiencies the player picks.
+	AbilityScores      []*ReferenceItem `json:"ability_scores"`
+	Feat               *ReferenceItem   `json:"feat"`
+	ProficiencyChoices []*ChoiceOption  `json:"proficiency_choices"`
 }
 
 type BackgroundFeature struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
-}
\ No newline at end of file
+}
+
+// Clone returns a deep copy of the background
+func (b *Background) Clone() *Background {
+	if b == nil {
+		return nil
+	}
+
+	out := *b
+	out.SkillProficiencies = cloneReferenceItems(b.SkillProficiencies)
+	out.LanguageOptions = b.LanguageOptions.Clone()
+	out.StartingEquipment = cloneSlice(b.StartingEquipment, (*StartingEquipment).Clone)
+	out.StartingEquipmentOptions = cloneSlice(b.StartingEquipmentOptions, (*ChoiceOption).Clone)
+	out.Feature = b.Feature.Clone()
+	out.PersonalityTraits = b.PersonalityTraits.Clone()
+	out.Ideals = b.Ideals.Clone()
+	out.Bonds = b.Bonds.Clone()
+	out.Flaws = b.Flaws.Clone()
+	out.AbilityScores = cloneReferenceItems(b.AbilityScores)
+	out.Feat = b.Feat.Clone()
+	out.ProficiencyChoices = cloneSlice(b.ProficiencyChoices, (*ChoiceOption).Clone)
+	return &out
+}
+
+// Clone returns a copy of the feature
+func (f *BackgroundFeature) Clone() *BackgroundFeature {
+	return clonePtr(f)
+}
@@ -52,3 +52,88 @@ func (o *MultipleOption) GetOptionType() OptionType {
 type OptionList struct {
 	Options []Option `json:"option_list"`
 }
+
+// Clone returns a deep copy of the option
+func (o *CountedReferenceOption) Clone() *CountedReferenceOption {
+	if o == nil {
+		return nil
+	}
+
+	out := *o
+	out.Reference = o.Reference.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the option
+func (o *ReferenceOption) Clone() *ReferenceOption {
+	if o == nil {
+		return nil
+	}
+
+	out := *o
+	out.Reference = o.Reference.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the choice
+func (o *ChoiceOption) Clone() *ChoiceOption {
+	if o == nil {
+		return nil
+	}
+
+	out := *o
+	out.OptionList = o.OptionList.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the option
+func (o *MultipleOption) Clone() *MultipleOption {
+	if o == nil {
+		return nil
+	}
+
+	out := *o
+	out.Items = cloneOptions(o.Items)
+	return &out
+}
+
+// Clone returns a deep copy of the option list
+func (o *OptionList) Clone() *OptionList {
+	if o == nil {
+		return nil
+	}
+
+	out := *o
+	out.Options = cloneOptions(o.Options)
+	return &out
+}
+
+// CloneOption returns a deep copy of an option of any of the types in this
+// package. Options of other types are returned as-is.
+func CloneOption(o Option) Option {
+	switch v := o.(type) {
+	case *CountedReferenceOption:
+		return v.Clone()
+	case *ReferenceOption:
+		return v.Clone()
+	case *ChoiceOption:
+		return v.Clone()
+	case *MultipleOption:
+		return v.Clone()
+	default:
+		return o
+	}
+}
+
+func cloneOptions(in []Option) []Option {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]Option, len(in))
+	for i, o := range in {
+		out[i] = CloneOption(o)
+	}
+
+	return out
+}
@@ -32,3 +32,57 @@ type SpellcastingInfo struct {
 	Name string   `json:"name"`
 	Desc []string `json:"desc"`
 }
+
+// Clone returns a deep copy of the class
+func (c *Class) Clone() *Class {
+	if c == nil {
+		return nil
+	}
+
+	out := *c
+	out.Proficiencies = cloneReferenceItems(c.Proficiencies)
+	out.SavingThrows = cloneReferenceItems(c.SavingThrows)
+	out.StartingEquipment = cloneSlice(c.StartingEquipment, (*StartingEquipment).Clone)
+	out.ProficiencyChoices = cloneSlice(c.ProficiencyChoices, (*ChoiceOption).Clone)
+	out.StartingEquipmentOptions = cloneSlice(c.StartingEquipmentOptions, (*ChoiceOption).Clone)
+	out.PrimaryAbilities = cloneReferenceItems(c.PrimaryAbilities)
+	out.ArmorProficiencies = cloneReferenceItems(c.ArmorProficiencies)
+	out.WeaponProficiencies = cloneReferenceItems(c.WeaponProficiencies)
+	out.ToolProficiencies = cloneReferenceItems(c.ToolProficiencies)
+	out.Spellcasting = c.Spellcasting.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the starting equipment
+func (s *StartingEquipment) Clone() *StartingEquipment {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.Equipment = s.Equipment.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the spellcasting details
+func (s *ClassSpellcasting) Clone() *ClassSpellcasting {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.SpellcastingAbility = s.SpellcastingAbility.Clone()
+	out.Info = cloneSlice(s.Info, (*SpellcastingInfo).Clone)
+	return &out
+}
+
+// Clone returns a deep copy of the spellcasting info
+func (s *SpellcastingInfo) Clone() *SpellcastingInfo {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.Desc = cloneStrings(s.Desc)
+	return &out
+}
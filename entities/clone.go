@@ -0,0 +1,39 @@
+package entities
+
+// clonePtr copies a struct that holds no pointers, slices or interfaces
+func clonePtr[T any](in *T) *T {
+	if in == nil {
+		return nil
+	}
+
+	out := *in
+	return &out
+}
+
+// cloneSlice deep-copies a slice of pointers with clone, keeping nil slices nil
+func cloneSlice[T any](in []*T, clone func(*T) *T) []*T {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*T, len(in))
+	for i, v := range in {
+		out[i] = clone(v)
+	}
+
+	return out
+}
+
+func cloneStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
+
+func cloneReferenceItems(in []*ReferenceItem) []*ReferenceItem {
+	return cloneSlice(in, (*ReferenceItem).Clone)
+}
@@ -6,3 +6,14 @@ type DamageType struct {
 	Type        string   `json:"type"`
 	Description []string `json:"desc"`
 }
+
+// Clone returns a deep copy of the damage type
+func (d *DamageType) Clone() *DamageType {
+	if d == nil {
+		return nil
+	}
+
+	out := *d
+	out.Description = cloneStrings(d.Description)
+	return &out
+}
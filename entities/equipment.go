@@ -30,6 +30,8 @@ type Weapon struct {
 	Range             *Range           `json:"weapon_range"`
 	Properties        []*ReferenceItem `json:"properties"`
 	TwoHandedDamage   *Damage          `json:"two_handed_damage"`
+	// Mastery is the weapon mastery property added by the 2024 rules
+	Mastery *ReferenceItem `json:"mastery"`
 }
 
 func (w *Weapon) GetType() string {
@@ -65,3 +67,71 @@ type ArmorClass struct {
 	Base     int  `json:"base"`
 	DexBonus bool `json:"dex_bonus"`
 }
+
+// Clone returns a deep copy of the equipment
+func (e *Equipment) Clone() *Equipment {
+	if e == nil {
+		return nil
+	}
+
+	out := *e
+	out.EquipmentCategory = e.EquipmentCategory.Clone()
+	out.Cost = e.Cost.Clone()
+	return &out
+}
+
+// Clone returns a copy of the cost
+func (c *Cost) Clone() *Cost {
+	return clonePtr(c)
+}
+
+// Clone returns a deep copy of the weapon
+func (w *Weapon) Clone() *Weapon {
+	if w == nil {
+		return nil
+	}
+
+	out := *w
+	out.EquipmentCategory = w.EquipmentCategory.Clone()
+	out.Cost = w.Cost.Clone()
+	out.Damage = w.Damage.Clone()
+	out.Range = w.Range.Clone()
+	out.Properties = cloneReferenceItems(w.Properties)
+	out.TwoHandedDamage = w.TwoHandedDamage.Clone()
+	out.Mastery = w.Mastery.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the damage
+func (d *Damage) Clone() *Damage {
+	if d == nil {
+		return nil
+	}
+
+	out := *d
+	out.DamageType = d.DamageType.Clone()
+	return &out
+}
+
+// Clone returns a copy of the range
+func (r *Range) Clone() *Range {
+	return clonePtr(r)
+}
+
+// Clone returns a deep copy of the armor
+func (a *Armor) Clone() *Armor {
+	if a == nil {
+		return nil
+	}
+
+	out := *a
+	out.EquipmentCategory = a.EquipmentCategory.Clone()
+	out.Cost = a.Cost.Clone()
+	out.ArmorClass = a.ArmorClass.Clone()
+	return &out
+}
+
+// Clone returns a copy of the armor class
+func (a *ArmorClass) Clone() *ArmorClass {
+	return clonePtr(a)
+}
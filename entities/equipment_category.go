@@ -6,4 +6,15 @@ type EquipmentCategory struct {
 	Name      string          `json:"name"`
 	Equipment []*ReferenceItem `json:"equipment"`
 	URL       string          `json:"url"`
-}
\ No newline at end of file
+}
+
+// Clone returns a deep copy of the equipment category
+func (e *EquipmentCategory) Clone() *EquipmentCategory {
+	if e == nil {
+		return nil
+	}
+
+	out := *e
+	out.Equipment = cloneReferenceItems(e.Equipment)
+	return &out
+}
@@ -12,3 +12,27 @@ type Feature struct {
 type SubFeatureOption struct {
 	SubFeatureOptions *ChoiceOption `json:"subfeature_options"`
 }
+
+// Clone returns a deep copy of the feature
+func (f *Feature) Clone() *Feature {
+	if f == nil {
+		return nil
+	}
+
+	out := *f
+	out.Class = f.Class.Clone()
+	out.FeatureSpecific = f.FeatureSpecific.Clone()
+	out.Invocations = cloneReferenceItems(f.Invocations)
+	return &out
+}
+
+// Clone returns a deep copy of the subfeature options
+func (s *SubFeatureOption) Clone() *SubFeatureOption {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.SubFeatureOptions = s.SubFeatureOptions.Clone()
+	return &out
+}
@@ -159,3 +159,155 @@ type WizardSpecific struct {
 func (w WizardSpecific) GetSpecificClass() string {
 	return "wizard"
 }
+
+// Clone returns a deep copy of the level
+func (l *Level) Clone() *Level {
+	if l == nil {
+		return nil
+	}
+
+	out := *l
+	out.Features = cloneReferenceItems(l.Features)
+	out.SpellCasting = l.SpellCasting.Clone()
+	out.ClassSpecific = CloneClassSpecific(l.ClassSpecific)
+	out.Class = l.Class.Clone()
+	return &out
+}
+
+// Clone returns a copy of the spell slots
+func (s *SpellCasting) Clone() *SpellCasting {
+	return clonePtr(s)
+}
+
+// CloneClassSpecific returns a deep copy of the class specific data of any of
+// the classes in this package. Other types are returned as-is.
+func CloneClassSpecific(c ClassSpecific) ClassSpecific {
+	switch v := c.(type) {
+	case *RangerSpecific:
+		return v.Clone()
+	case *BarbarianSpecific:
+		return v.Clone()
+	case *BardSpecific:
+		return v.Clone()
+	case *ClericSpecific:
+		return v.Clone()
+	case *DruidSpecific:
+		return v.Clone()
+	case *FighterSpecific:
+		return v.Clone()
+	case *MonkSpecific:
+		return v.Clone()
+	case *PaladinSpecific:
+		return v.Clone()
+	case *RogueSpecific:
+		return v.Clone()
+	case *SorcererSpecific:
+		return v.Clone()
+	case *WarlockSpecific:
+		return v.Clone()
+	case *WizardSpecific:
+		return v.Clone()
+	// value types are already copies, except for what they point to
+	case MonkSpecific:
+		return *v.Clone()
+	case RogueSpecific:
+		return *v.Clone()
+	case SorcererSpecific:
+		return *v.Clone()
+	default:
+		return c
+	}
+}
+
+// Clone returns a copy of the ranger specifics
+func (r *RangerSpecific) Clone() *RangerSpecific {
+	return clonePtr(r)
+}
+
+// Clone returns a copy of the barbarian specifics
+func (b *BarbarianSpecific) Clone() *BarbarianSpecific {
+	return clonePtr(b)
+}
+
+// Clone returns a copy of the bard specifics
+func (b *BardSpecific) Clone() *BardSpecific {
+	return clonePtr(b)
+}
+
+// Clone returns a copy of the cleric specifics
+func (c *ClericSpecific) Clone() *ClericSpecific {
+	return clonePtr(c)
+}
+
+// Clone returns a copy of the druid specifics
+func (d *DruidSpecific) Clone() *DruidSpecific {
+	return clonePtr(d)
+}
+
+// Clone returns a copy of the fighter specifics
+func (f *FighterSpecific) Clone() *FighterSpecific {
+	return clonePtr(f)
+}
+
+// Clone returns a deep copy of the monk specifics
+func (m *MonkSpecific) Clone() *MonkSpecific {
+	if m == nil {
+		return nil
+	}
+
+	out := *m
+	out.MartialArts = m.MartialArts.Clone()
+	return &out
+}
+
+// Clone returns a copy of the martial arts dice
+func (m *MartialArts) Clone() *MartialArts {
+	return clonePtr(m)
+}
+
+// Clone returns a copy of the paladin specifics
+func (p *PaladinSpecific) Clone() *PaladinSpecific {
+	return clonePtr(p)
+}
+
+// Clone returns a deep copy of the rogue specifics
+func (r *RogueSpecific) Clone() *RogueSpecific {
+	if r == nil {
+		return nil
+	}
+
+	out := *r
+	out.SneakAttack = r.SneakAttack.Clone()
+	return &out
+}
+
+// Clone returns a copy of the sneak attack dice
+func (s *SneakAttack) Clone() *SneakAttack {
+	return clonePtr(s)
+}
+
+// Clone returns a deep copy of the sorcerer specifics
+func (s *SorcererSpecific) Clone() *SorcererSpecific {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.CreatingSpellSlots = cloneSlice(s.CreatingSpellSlots, (*CreatingSpellSlots).Clone)
+	return &out
+}
+
+// Clone returns a copy of the spell slot conversion
+func (c *CreatingSpellSlots) Clone() *CreatingSpellSlots {
+	return clonePtr(c)
+}
+
+// Clone returns a copy of the warlock specifics
+func (w *WarlockSpecific) Clone() *WarlockSpecific {
+	return clonePtr(w)
+}
+
+// Clone returns a copy of the wizard specifics
+func (w *WizardSpecific) Clone() *WizardSpecific {
+	return clonePtr(w)
+}
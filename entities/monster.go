@@ -86,3 +86,76 @@ type MonsterAction struct {
 	Description string    `json:"desc"`
 	Damage      []*Damage `json:"damage"`
 }
+
+// Clone returns a deep copy of the monster
+func (m *Monster) Clone() *Monster {
+	if m == nil {
+		return nil
+	}
+
+	out := *m
+	out.Speed = m.Speed.Clone()
+	out.Proficiencies = cloneSlice(m.Proficiencies, (*MonsterProficiency).Clone)
+	out.DamageVulnerabilities = cloneStrings(m.DamageVulnerabilities)
+	out.DamageResistances = cloneStrings(m.DamageResistances)
+	out.DamageImmunities = cloneStrings(m.DamageImmunities)
+	out.ConditionImmunities = cloneReferenceItems(m.ConditionImmunities)
+	out.MonsterSenses = m.MonsterSenses.Clone()
+	out.MonsterActions = cloneSlice(m.MonsterActions, (*MonsterAction).Clone)
+	return &out
+}
+
+// Clone returns a copy of the speed
+func (s *Speed) Clone() *Speed {
+	return clonePtr(s)
+}
+
+// Clone returns a deep copy of the proficiency
+func (m *MonsterProficiency) Clone() *MonsterProficiency {
+	if m == nil {
+		return nil
+	}
+
+	out := *m
+	out.Proficiency = m.Proficiency.Clone()
+	return &out
+}
+
+// Clone returns a copy of the senses
+func (m *MonsterSenses) Clone() *MonsterSenses {
+	return clonePtr(m)
+}
+
+// Clone returns a deep copy of the special ability
+func (s *SpecialAbility) Clone() *SpecialAbility {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.Description = cloneStrings(s.Description)
+	out.Usage = s.Usage.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the usage
+func (u *Usage) Clone() *Usage {
+	if u == nil {
+		return nil
+	}
+
+	out := *u
+	out.UsageRestTypes = cloneStrings(u.UsageRestTypes)
+	return &out
+}
+
+// Clone returns a deep copy of the action
+func (m *MonsterAction) Clone() *MonsterAction {
+	if m == nil {
+		return nil
+	}
+
+	out := *m
+	out.Damage = cloneSlice(m.Damage, (*Damage).Clone)
+	return &out
+}
@@ -18,3 +18,14 @@ type Proficiency struct {
 	Type      ProficiencyType `json:"type"`
 	Reference *ReferenceItem  `json:"reference"`
 }
+
+// Clone returns a deep copy of the proficiency
+func (p *Proficiency) Clone() *Proficiency {
+	if p == nil {
+		return nil
+	}
+
+	out := *p
+	out.Reference = p.Reference.Clone()
+	return &out
+}
@@ -13,9 +13,39 @@ type Race struct {
 	StartingProficiencies      []*ReferenceItem `json:"starting_proficiencies"`
 	StartingProficiencyOptions *ChoiceOption    `json:"starting_proficiency_options"`
 	LanguageOptions            *ChoiceOption    `json:"language_options"`
+	// CreatureType is set by the 2024 rules, e.g. "Humanoid"
+	CreatureType string `json:"creature_type"`
 }
 
 type AbilityBonus struct {
 	AbilityScore *ReferenceItem `json:"ability_score"`
 	Bonus        int            `json:"bonus"`
 }
+
+// Clone returns a deep copy of the race
+func (r *Race) Clone() *Race {
+	if r == nil {
+		return nil
+	}
+
+	out := *r
+	out.AbilityBonuses = cloneSlice(r.AbilityBonuses, (*AbilityBonus).Clone)
+	out.Languages = cloneReferenceItems(r.Languages)
+	out.Traits = cloneReferenceItems(r.Traits)
+	out.SubRaces = cloneReferenceItems(r.SubRaces)
+	out.StartingProficiencies = cloneReferenceItems(r.StartingProficiencies)
+	out.StartingProficiencyOptions = r.StartingProficiencyOptions.Clone()
+	out.LanguageOptions = r.LanguageOptions.Clone()
+	return &out
+}
+
+// Clone returns a deep copy of the ability bonus
+func (a *AbilityBonus) Clone() *AbilityBonus {
+	if a == nil {
+		return nil
+	}
+
+	out := *a
+	out.AbilityScore = a.AbilityScore.Clone()
+	return &out
+}
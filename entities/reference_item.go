@@ -5,3 +5,8 @@ type ReferenceItem struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
 }
+
+// Clone returns a copy of the reference
+func (r *ReferenceItem) Clone() *ReferenceItem {
+	return clonePtr(r)
+}
@@ -7,3 +7,15 @@ type Skill struct {
 	AbilityScore *ReferenceItem `json:"ability_score"`
 	Type         string         `json:"type"`
 }
+
+// Clone returns a deep copy of the skill
+func (s *Skill) Clone() *Skill {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.Description = cloneStrings(s.Description)
+	out.AbilityScore = s.AbilityScore.Clone()
+	return &out
+}
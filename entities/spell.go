@@ -43,3 +43,51 @@ type AreaOfEffect struct {
 	Type string `json:"type"`
 	Size int    `json:"size"`
 }
+
+// Clone returns a deep copy of the spell
+func (s *Spell) Clone() *Spell {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.SpellDamage = s.SpellDamage.Clone()
+	out.DC = s.DC.Clone()
+	out.AreaOfEffect = s.AreaOfEffect.Clone()
+	out.SpellSchool = s.SpellSchool.Clone()
+	out.SpellClasses = cloneReferenceItems(s.SpellClasses)
+	return &out
+}
+
+// Clone returns a deep copy of the spell damage
+func (s *SpellDamage) Clone() *SpellDamage {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+	out.SpellDamageType = s.SpellDamageType.Clone()
+	out.SpellDamageAtSlotLevel = s.SpellDamageAtSlotLevel.Clone()
+	return &out
+}
+
+// Clone returns a copy of the damage per slot level
+func (s *SpellDamageAtSlotLevel) Clone() *SpellDamageAtSlotLevel {
+	return clonePtr(s)
+}
+
+// Clone returns a deep copy of the DC
+func (d *DC) Clone() *DC {
+	if d == nil {
+		return nil
+	}
+
+	out := *d
+	out.DCType = d.DCType.Clone()
+	return &out
+}
+
+// Clone returns a copy of the area of effect
+func (a *AreaOfEffect) Clone() *AreaOfEffect {
+	return clonePtr(a)
+}
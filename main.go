@@ -1,14 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"time"
 
 	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		if err := runSnapshot(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Create HTTP client with reasonable timeout
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
@@ -56,4 +70,64 @@ func main() {
 	}
 
 	log.Println("D&D 5e API client with caching initialized successfully")
-}
\ No newline at end of file
+}
+
+// runSnapshot downloads the whole API into a local snapshot for the offline
+// client, or verifies an existing one. -rules picks the 2014 or 2024 rules;
+// -version only names the snapshot directory:
+//
+//	go run . snapshot -dir srd -rules 2024 -version 2026-10-17 -concurrency 8 -resume
+//	go run . snapshot -dir srd -version 2026-10-17 -verify
+func runSnapshot(args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	dir := flags.String("dir", "snapshots", "directory to write snapshots to")
+	version := flags.String("version", "", "snapshot name, defaults to today's date")
+	rules := flags.String("rules", "", "rules version to download, 2014 or 2024; defaults to the unversioned 2014 routes")
+	baseURL := flags.String("base-url", "", "API base URL, defaults to the public API")
+	concurrency := flags.Int("concurrency", 8, "maximum requests in flight")
+	rps := flags.Float64("rps", 20, "maximum requests per second")
+	resume := flags.Bool("resume", false, "keep files from an earlier run and download only what is missing")
+	verify := flags.Bool("verify", false, "check an existing snapshot against its manifest instead of downloading")
+	flags.Parse(args)
+
+	if *verify {
+		if *version == "" {
+			return errors.New("-version is required with -verify")
+		}
+
+		manifest, err := dnd5e.VerifySnapshot(filepath.Join(*dir, *version))
+		if err != nil {
+			return fmt.Errorf("snapshot is invalid: %w", err)
+		}
+
+		log.Printf("Snapshot %s is valid: %d files", manifest.Version, len(manifest.Files))
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start := time.Now()
+	manifest, err := dnd5e.Snapshot(ctx, &dnd5e.SnapshotConfig{
+		API: &dnd5e.DND5eAPIConfig{
+			Client:       &http.Client{Timeout: 30 * time.Second},
+			BaseURL:      *baseURL,
+			Retry:        dnd5e.DefaultRetryPolicy(),
+			RateLimiter:  dnd5e.NewRateLimiter(*rps, *concurrency),
+			RulesVersion: dnd5e.RulesVersion(*rules),
+		},
+		Dir:         *dir,
+		Version:     *version,
+		Concurrency: *concurrency,
+		Resume:      *resume,
+	})
+	if err != nil {
+		if manifest != nil {
+			log.Printf("Saved %d files; run again with -resume to fetch the rest", len(manifest.Files))
+		}
+		return fmt.Errorf("snapshot failed: %w", err)
+	}
+
+	log.Printf("Saved snapshot %s with %d files to %s in %s", manifest.Version, len(manifest.Files), filepath.Join(*dir, manifest.Version), time.Since(start).Round(time.Second))
+	return nil
+}